@@ -0,0 +1,174 @@
+package internal
+
+import (
+	stdCtx "context"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/cmd/internal/flags"
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/daemon"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func RegisterDaemonCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run executables on their configured schedule.",
+		Long: "Run the flow scheduler in the foreground. Executables with a `schedule` are run non-interactively " +
+			"when they are due and the output of each run is saved to a log file. A run that was missed while the " +
+			"daemon was stopped is caught up once when it is started again, and a run is skipped if the previous run " +
+			"of the executable is still in progress.\n\nUse a service manager (e.g. systemd or launchd) to keep the " +
+			"daemon running in the background.",
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			execPreRun(ctx, cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			daemonFunc(ctx, cmd, args)
+		},
+	}
+	registerDaemonStatusCmd(ctx, subCmd)
+	rootCmd.AddCommand(subCmd)
+}
+
+func daemonFunc(ctx *context.Context, _ *cobra.Command, _ []string) {
+	logger := ctx.Logger
+	if err := filesystem.EnsureDaemonDir(); err != nil {
+		logger.FatalErr(err)
+	}
+	// Scheduled executables cannot prompt for input or display the terminal UI.
+	_ = os.Setenv("DISABLE_FLOW_INTERACTIVE", "true")
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		logger.Infof("stopping daemon; interrupting runs in progress")
+		ctx.CancelFunc()
+	}()
+
+	scheduler, err := daemon.NewScheduler(ctx, daemon.NewSystemClock(), runScheduled, filesystem.DaemonDir())
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	logger.Infof("daemon started; logs are saved to %s", filesystem.DaemonLogsDir())
+	load := func() (executable.ExecutableList, error) {
+		if err := ctx.ExecutableCache.Update(logger); err != nil {
+			return nil, err
+		}
+		return ctx.ExecutableCache.GetExecutableList(logger)
+	}
+	if err := scheduler.Run(load); err != nil {
+		logger.FatalErr(err)
+	}
+}
+
+// runScheduled runs the executable with its default argument values in a `flow` subprocess instead of calling
+// runner.Exec directly. Runs share the daemon process, so a separate process keeps the output of each run in its own
+// log file and gives each run its own environment and process store, the same as a run from the terminal.
+// The subprocess inherits DISABLE_FLOW_INTERACTIVE from the daemon and has no stdin, so params and prompt steps
+// that need an answer fail the run instead of waiting for input.
+func runScheduled(ctx stdCtx.Context, e *executable.Executable, out stdio.Writer) error {
+	return runInSubprocess(ctx, []string{e.Verb.String(), e.ID()}, out)
+}
+
+func registerDaemonStatusCmd(ctx *context.Context, daemonCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the daemon and its scheduled executables.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			daemonStatusFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.OutputFormatFlag)
+	daemonCmd.AddCommand(subCmd)
+}
+
+func daemonStatusFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
+	logger := ctx.Logger
+	state, err := daemon.LoadState(filesystem.DaemonDir())
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	if !state.Running() {
+		state.PID = 0
+	}
+
+	outputFormat := flags.ValueFor[string](ctx, cmd, *flags.OutputFormatFlag, false)
+	switch strings.ToLower(outputFormat) {
+	case "yaml", "yml":
+		data, err := yaml.Marshal(state)
+		if err != nil {
+			logger.Fatalf("Failed to marshal daemon status - %v", err)
+		}
+		logger.Println(string(data))
+	case "json":
+		data, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			logger.Fatalf("Failed to marshal daemon status - %v", err)
+		}
+		logger.Println(string(data))
+	case "":
+		printDaemonStatus(ctx, state)
+	default:
+		logger.Fatalf("Unsupported output format %s", outputFormat)
+	}
+}
+
+func printDaemonStatus(ctx *context.Context, state *daemon.State) {
+	if state.PID != 0 {
+		ctx.Logger.PlainTextSuccess(fmt.Sprintf(
+			"daemon is running (pid %d) since %s", state.PID, state.StartedAt.Local().Format(time.RFC822),
+		))
+	} else {
+		ctx.Logger.PlainTextWarn("daemon is not running")
+	}
+	if len(state.Executables) == 0 {
+		ctx.Logger.PlainTextInfo("No scheduled executables found")
+		return
+	}
+
+	refs := make([]string, 0, len(state.Executables))
+	for ref := range state.Executables {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+
+	w := tabwriter.NewWriter(ctx.StdOut(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\nEXECUTABLE\tSCHEDULE\tLAST RUN\tSTATUS\tNEXT RUN\tRUNS\tFAILURES\tSKIPPED")
+	for _, ref := range refs {
+		execState := state.Executables[ref]
+		lastRun, status := "-", "-"
+		if execState.LastRun != nil {
+			lastRun = execState.LastRun.Start.Local().Format(time.RFC822)
+			status = string(execState.LastRun.Status)
+			if execState.LastRun.CatchUp {
+				status += " (catch-up)"
+			}
+		}
+		nextRun := "-"
+		if !execState.NextRun.IsZero() {
+			nextRun = execState.NextRun.Local().Format(time.RFC822)
+		}
+		_, _ = fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			ref, execState.Schedule, lastRun, status, nextRun,
+			execState.Runs, execState.Failures, execState.Skipped,
+		)
+	}
+	_ = w.Flush()
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -11,19 +12,24 @@ import (
 	"github.com/jahvon/tuikit/views"
 	"github.com/spf13/cobra"
 
+	"github.com/jahvon/flow/cmd/internal/flags"
 	"github.com/jahvon/flow/internal/cache"
 	"github.com/jahvon/flow/internal/context"
 	"github.com/jahvon/flow/internal/io"
 	"github.com/jahvon/flow/internal/runner"
 	"github.com/jahvon/flow/internal/runner/engine"
 	"github.com/jahvon/flow/internal/runner/exec"
+	"github.com/jahvon/flow/internal/runner/fs"
 	"github.com/jahvon/flow/internal/runner/launch"
 	"github.com/jahvon/flow/internal/runner/parallel"
 	"github.com/jahvon/flow/internal/runner/render"
 	"github.com/jahvon/flow/internal/runner/request"
 	"github.com/jahvon/flow/internal/runner/serial"
+	"github.com/jahvon/flow/internal/runner/service"
+	"github.com/jahvon/flow/internal/runner/wait"
 	"github.com/jahvon/flow/internal/services/store"
 	argUtils "github.com/jahvon/flow/internal/utils/args"
+	execUtils "github.com/jahvon/flow/internal/utils/executables"
 	"github.com/jahvon/flow/internal/vault"
 	"github.com/jahvon/flow/types/executable"
 )
@@ -61,6 +67,10 @@ func RegisterExecCmd(ctx *context.Context, rootCmd *cobra.Command) {
 			execFunc(ctx, cmd, verb, args)
 		},
 	}
+	RegisterFlag(ctx, subCmd, *flags.NoTUIFlag)
+	RegisterFlag(ctx, subCmd, *flags.WatchFlag)
+	RegisterFlag(ctx, subCmd, *flags.AssumeYesFlag)
+	RegisterFlag(ctx, subCmd, *flags.AnswersFileFlag)
 	rootCmd.AddCommand(subCmd)
 }
 
@@ -71,6 +81,9 @@ func execPreRun(_ *context.Context, _ *cobra.Command, _ []string) {
 	runner.RegisterRunner(render.NewRunner())
 	runner.RegisterRunner(serial.NewRunner())
 	runner.RegisterRunner(parallel.NewRunner())
+	runner.RegisterRunner(service.NewRunner())
+	runner.RegisterRunner(wait.NewRunner())
+	runner.RegisterRunner(fs.NewRunner())
 }
 
 // TODO: refactor this function to simplify the logic
@@ -108,6 +121,13 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 		))
 	}
 
+	watchMode := flags.ValueFor[bool](ctx, cmd, *flags.WatchFlag, false)
+	if watchMode && e.Render != nil {
+		// Render executables reload their content in place instead of being re-run.
+		e.Render.Watch = true
+		watchMode = false
+	}
+
 	execArgs := args[1:]
 	envMap, err := argUtils.ProcessArgs(e, execArgs, nil)
 	if err != nil {
@@ -125,6 +145,11 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 		envMap = make(map[string]string)
 	}
 
+	if flags.ValueFor[bool](ctx, cmd, *flags.NoTUIFlag, false) || !TUIEnabled(ctx, cmd) {
+		// Runners check this variable to route output that is normally displayed in the TUI to stdout.
+		_ = os.Setenv("DISABLE_FLOW_INTERACTIVE", "true")
+	}
+	setPromptEnv(ctx, cmd)
 	setAuthEnv(ctx, cmd, e)
 	textInputs := pendingFormFields(ctx, e)
 	if len(textInputs) > 0 {
@@ -141,7 +166,11 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 	}
 	startTime := time.Now()
 	eng := engine.NewExecEngine()
-	if err := runner.Exec(ctx, e, eng, envMap); err != nil {
+	execute := runner.Exec
+	if watchMode {
+		execute = runner.ExecWatch
+	}
+	if err := execute(ctx, e, eng, envMap); err != nil {
 		logger.FatalErr(err)
 	}
 	dur := time.Since(startTime)
@@ -197,6 +226,25 @@ func runByRef(ctx *context.Context, cmd *cobra.Command, argsStr string) error {
 	return nil
 }
 
+// setPromptEnv sets the variables that runners check to answer prompt steps without displaying a form.
+func setPromptEnv(ctx *context.Context, cmd *cobra.Command) {
+	if flags.ValueFor[bool](ctx, cmd, *flags.AssumeYesFlag, false) {
+		_ = os.Setenv(executable.AssumeYesEnv, "true")
+	}
+	answersFile := flags.ValueFor[string](ctx, cmd, *flags.AnswersFileFlag, false)
+	if answersFile == "" {
+		return
+	}
+	path, err := filepath.Abs(answersFile)
+	if err != nil {
+		ctx.Logger.FatalErr(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		ctx.Logger.FatalErr(fmt.Errorf("answers file not found\n%w", err))
+	}
+	_ = os.Setenv(executable.AnswersFileEnv, path)
+}
+
 func setAuthEnv(ctx *context.Context, _ *cobra.Command, executable *executable.Executable) {
 	if authRequired(ctx, executable) {
 		form, err := views.NewForm(
@@ -224,136 +272,32 @@ func setAuthEnv(ctx *context.Context, _ *cobra.Command, executable *executable.E
 	}
 }
 
-// TODO: refactor this function to simplify the logic
-//
-//nolint:all
+// authRequired returns true if the executable, or an executable that it references, uses a secret.
 func authRequired(ctx *context.Context, rootExec *executable.Executable) bool {
 	if os.Getenv(vault.EncryptionKeyEnvVar) != "" {
 		return false
 	}
-	switch {
-	case rootExec.Exec != nil:
-		for _, param := range rootExec.Exec.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-	case rootExec.Launch != nil:
-		for _, param := range rootExec.Launch.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-	case rootExec.Request != nil:
-		for _, param := range rootExec.Request.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-	case rootExec.Render != nil:
-		for _, param := range rootExec.Render.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-	case rootExec.Serial != nil:
-		for _, param := range rootExec.Serial.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-		for _, e := range rootExec.Serial.Execs {
-			if e.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, e.Ref)
-				if err != nil {
-					continue
-				}
-				if authRequired(ctx, childExec) {
+	for _, e := range execUtils.ExecutableTree(ctx, rootExec) {
+		if env := e.Env(); env != nil {
+			for _, param := range env.Params {
+				if param.SecretRef != "" {
 					return true
 				}
 			}
 		}
-	case rootExec.Parallel != nil:
-		for _, param := range rootExec.Parallel.Params {
-			if param.SecretRef != "" {
-				return true
-			}
-		}
-		for _, e := range rootExec.Parallel.Execs {
-			if e.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, e.Ref)
-				if err != nil {
-					continue
-				}
-				if authRequired(ctx, childExec) {
-					return true
-				}
-			}
+		if e.Request != nil &&
+			(len(e.Request.SecretRefs()) > 0 || len(ctx.Config.HTTPClientConfig().SecretRefs()) > 0) {
+			return true
 		}
 	}
 	return false
 }
 
-//nolint:gocognit
+// pendingFormFields returns the prompts of the params of the executable and the executables that it references.
 func pendingFormFields(ctx *context.Context, rootExec *executable.Executable) []*views.FormField {
 	pending := make([]*views.FormField, 0)
-	switch {
-	case rootExec.Exec != nil:
-		for _, param := range rootExec.Exec.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-	case rootExec.Launch != nil:
-		for _, param := range rootExec.Launch.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-	case rootExec.Request != nil:
-		for _, param := range rootExec.Request.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-	case rootExec.Render != nil:
-		for _, param := range rootExec.Render.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-	case rootExec.Serial != nil:
-		for _, param := range rootExec.Serial.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-		for _, child := range rootExec.Serial.Execs {
-			if child.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, child.Ref)
-				if err != nil {
-					continue
-				}
-				childPending := pendingFormFields(ctx, childExec)
-				pending = append(pending, childPending...)
-			}
-		}
-	case rootExec.Parallel != nil:
-		for _, param := range rootExec.Parallel.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
-		}
-		for _, child := range rootExec.Parallel.Execs {
-			if child.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, child.Ref)
-				if err != nil {
-					continue
-				}
-				childPending := pendingFormFields(ctx, childExec)
-				pending = append(pending, childPending...)
-			}
-		}
+	for _, param := range execUtils.PromptParams(ctx, rootExec) {
+		pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
 	}
 	return pending
 }
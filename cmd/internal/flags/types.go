@@ -118,6 +118,54 @@ var NonInteractiveFlag = &Metadata{
 	Required: false,
 }
 
+var NoTUIFlag = &Metadata{
+	Name: "no-tui",
+	Usage: "Run the executable without the terminal UI. " +
+		"Content that is normally displayed in the terminal UI, such as rendered markdown, is printed to stdout.",
+	Default:  false,
+	Required: false,
+}
+
+var WatchFlag = &Metadata{
+	Name: "watch",
+	Usage: "Re-run the executable when the files matching its watch configuration change. " +
+		"Render executables are re-rendered when their template or data files change.",
+	Default:  false,
+	Required: false,
+}
+
+var AssumeYesFlag = &Metadata{
+	Name:      "yes",
+	Shorthand: "y",
+	Usage: "Answer prompt steps with their default answers and accept all confirmations. " +
+		"This is useful for running executables that prompt for input non-interactively.",
+	Default:  false,
+	Required: false,
+}
+
+var AnswersFileFlag = &Metadata{
+	Name: "answers-file",
+	Usage: "Path to a YAML or JSON file that maps prompt keys to their answers. " +
+		"Prompt steps only ask the questions that are not answered in the file.",
+	Default:  "",
+	Required: false,
+}
+
+var StopAllFlag = &Metadata{
+	Name:     "all",
+	Usage:    "Stop all services that were started by flow.",
+	Default:  false,
+	Required: false,
+}
+
+var FollowFlag = &Metadata{
+	Name:      "follow",
+	Shorthand: "f",
+	Usage:     "Continue printing the output as it is written.",
+	Default:   false,
+	Required:  false,
+}
+
 var CopyFlag = &Metadata{
 	Name:     "copy",
 	Usage:    "Copy the secret value to the clipboard",
@@ -175,3 +223,26 @@ var StoreFullFlag = &Metadata{
 	Usage:   "Force clear all stored data",
 	Default: false,
 }
+
+var AddressFlag = &Metadata{
+	Name:     "address",
+	Usage:    "The address that the server listens on. Overrides the `server.address` config.",
+	Default:  "",
+	Required: false,
+}
+
+var GraphFormatFlag = &Metadata{
+	Name:      "output",
+	Shorthand: "o",
+	Usage:     "Output format. One of: dot, mermaid, json, or tree.",
+	Default:   "",
+	Required:  false,
+}
+
+var ValidateOutputFormatFlag = &Metadata{
+	Name:      "output",
+	Shorthand: "o",
+	Usage:     "Output format of the validation report. One of: yaml or json.",
+	Default:   "",
+	Required:  false,
+}
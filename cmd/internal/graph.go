@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jahvon/flow/cmd/internal/flags"
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/graph"
+	"github.com/jahvon/flow/internal/io/viewer"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func RegisterGraphCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "graph [workspace|VERB ID]",
+		Short: "Show the dependency graph of serial and parallel executables.",
+		Long: "Show the executables that are run by the refs of serial and parallel executables. The graph of a single " +
+			"executable, or of all executables in a workspace, can be shown. The current workspace is used if no " +
+			"argument is given.\n\n" +
+			"Edges are labeled with the step number, `if` condition, and retries of the ref. The graph can be " +
+			"exported as Graphviz DOT, a Mermaid flowchart, or JSON with the output flag.",
+		Args: cobra.MaximumNArgs(2),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			if graphTUIEnabled(ctx, cmd) {
+				StartTUI(ctx, cmd)
+			}
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			if graphTUIEnabled(ctx, cmd) {
+				WaitForTUI(ctx, cmd)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			graphFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.GraphFormatFlag)
+	rootCmd.AddCommand(subCmd)
+}
+
+func graphFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	logger := ctx.Logger
+	g, err := graphForArgs(ctx, args)
+	if err != nil {
+		logger.FatalErr(err)
+	}
+
+	outputFormat := flags.ValueFor[string](ctx, cmd, *flags.GraphFormatFlag, false)
+	switch strings.ToLower(outputFormat) {
+	case "dot":
+		logger.Println(g.DOT())
+	case "mermaid":
+		logger.Println(g.Mermaid())
+	case "json":
+		str, err := g.JSON()
+		if err != nil {
+			logger.Fatalf("Failed to marshal graph - %v", err)
+		}
+		logger.Println(str)
+	case "", "tree":
+		switch {
+		case graphTUIEnabled(ctx, cmd):
+			view := viewer.NewItemTreeView(ctx.TUIContainer.RenderState(), graphTreeItems(g.TreeNodes()))
+			SetView(ctx, cmd, view)
+		case len(g.Roots) == 0:
+			logger.Println("No serial or parallel executables found")
+		default:
+			logger.Println(strings.TrimSuffix(g.Tree(), "\n"))
+		}
+	default:
+		logger.Fatalf("Unsupported output format %s", outputFormat)
+	}
+}
+
+// graphTUIEnabled returns true when the tree view is shown in the TUI. Exported formats are always printed so that
+// they can be piped to other tools.
+func graphTUIEnabled(ctx *context.Context, cmd *cobra.Command) bool {
+	outputFormat := flags.ValueFor[string](ctx, cmd, *flags.GraphFormatFlag, false)
+	return outputFormat == "" && TUIEnabled(ctx, cmd)
+}
+
+func graphTreeItems(nodes []*graph.TreeNode) []*viewer.TreeItem {
+	items := make([]*viewer.TreeItem, 0, len(nodes))
+	for _, n := range nodes {
+		items = append(items, &viewer.TreeItem{Label: n.Label(), Children: graphTreeItems(n.Children)})
+	}
+	return items
+}
+
+// graphForArgs builds the graph of the executable ref or workspace in the args.
+func graphForArgs(ctx *context.Context, args []string) (*graph.Graph, error) {
+	verb, id, isRef := strings.Cut(strings.Join(args, " "), " ")
+	if isRef {
+		ref := context.ExpandRef(ctx, executable.NewRef(id, executable.Verb(verb)))
+		if err := ref.Validate(); err != nil {
+			return nil, err
+		}
+		e, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, ref)
+		if err != nil {
+			return nil, err
+		} else if e == nil {
+			return nil, fmt.Errorf("executable %s not found", ref)
+		}
+		return graph.ForExecutable(ctx, e), nil
+	}
+
+	ws := workspaceOrCurrent(ctx, verb)
+	if ws == nil {
+		return nil, fmt.Errorf("workspace %s not found", verb)
+	}
+	execs, err := ctx.ExecutableCache.GetExecutableList(ctx.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return graph.ForWorkspace(ctx, execs.FilterByWorkspace(ws.AssignedName())), nil
+}
@@ -1,8 +1,14 @@
 package internal
 
 import (
+	stdCtx "context"
+	"fmt"
+	stdio "io"
 	"os"
-	"strconv"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/jahvon/tuikit"
 	"github.com/spf13/cobra"
@@ -11,6 +17,7 @@ import (
 	"github.com/jahvon/flow/internal/context"
 	"github.com/jahvon/flow/internal/filesystem"
 	"github.com/jahvon/flow/internal/io"
+	"github.com/jahvon/flow/internal/services/store"
 	"github.com/jahvon/flow/types/executable"
 	"github.com/jahvon/flow/types/workspace"
 )
@@ -56,8 +63,7 @@ func MarkFlagFilename(ctx *context.Context, cmd *cobra.Command, name string) {
 
 func TUIEnabled(ctx *context.Context, cmd *cobra.Command) bool {
 	disabled := flags.ValueFor[bool](ctx, cmd.Root(), *flags.NonInteractiveFlag, true)
-	envDisabled, _ := strconv.ParseBool(os.Getenv("DISABLE_FLOW_INTERACTIVE"))
-	return !disabled && !envDisabled && ctx.Config.ShowTUI()
+	return !disabled && ctx.ShowTUI()
 }
 
 func SetView(ctx *context.Context, cmd *cobra.Command, view tuikit.View) {
@@ -138,3 +144,27 @@ func loadFlowfileTemplate(ctx *context.Context, name, path string) *executable.T
 	}
 	return tmpl
 }
+
+// subprocessWaitDelay is how long a subprocess has to exit after it is interrupted before it is killed.
+const subprocessWaitDelay = 10 * time.Second
+
+// runInSubprocess runs `flow [args...]` in a separate process, so that the environment variables and store bucket
+// set by the run are not shared with other runs of the same process. The output of the run is written to out. The
+// process is interrupted when the context is cancelled.
+func runInSubprocess(ctx stdCtx.Context, args []string, out stdio.Writer) error {
+	bin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the flow executable - %w", err)
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		return strings.HasPrefix(kv, store.BucketEnv+"=")
+	})
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = subprocessWaitDelay
+	return cmd.Run()
+}
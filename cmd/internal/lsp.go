@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"os"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/spf13/cobra"
+
+	"github.com/jahvon/flow/internal/context"
+	flowIO "github.com/jahvon/flow/internal/io"
+	"github.com/jahvon/flow/internal/lsp"
+)
+
+func RegisterLSPCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Start a language server for editing flowfiles.",
+		Long: "Start a Language Server Protocol server that communicates over stdin and stdout. It provides " +
+			"completion for verbs, executable references, secret references, and the arguments of serial and " +
+			"parallel executables. It also provides go-to-definition and hover documentation for executable " +
+			"references and reports validation errors and references that cannot be resolved.\n\n" +
+			"Configure your editor to start `flow lsp` for files with the .flow extension.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			lspFunc(ctx, cmd, args)
+		},
+	}
+	rootCmd.AddCommand(subCmd)
+}
+
+func lspFunc(ctx *context.Context, _ *cobra.Command, _ []string) {
+	// Stdout is reserved for the protocol messages, so logs are written to stderr instead.
+	ctx.Logger = io.NewLogger(os.Stderr, flowIO.Theme(ctx.Config.Theme.String()), io.Text, "")
+	if err := lsp.NewServer(ctx).Serve(ctx.StdIn(), ctx.StdOut()); err != nil {
+		ctx.Logger.FatalErr(err)
+	}
+}
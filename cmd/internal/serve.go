@@ -0,0 +1,107 @@
+package internal
+
+import (
+	stdCtx "context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	stdio "io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jahvon/flow/cmd/internal/flags"
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/internal/server"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// ServeTokenEnv is the environment variable that the API token is read from.
+const ServeTokenEnv = "FLOW_SERVE_TOKEN"
+
+func RegisterServeCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start a local REST API for listing and running executables.",
+		Long: "Start a REST API that lists workspaces and executables, shows an executable's documentation, runs " +
+			"executables, and streams the output of a run as server-sent events.\n\n" +
+			"Every request must include the token set in the " + ServeTokenEnv + " environment variable as a bearer " +
+			"token. If it is not set, a token is generated and printed when the server starts. Only executables " +
+			"allowed by the `server.allowedExecutables` and `server.allowedVisibilities` config can be run.",
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			execPreRun(ctx, cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			serveFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.AddressFlag)
+	rootCmd.AddCommand(subCmd)
+}
+
+func serveFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
+	logger := ctx.Logger
+	if err := filesystem.EnsureServerRunsDir(); err != nil {
+		logger.FatalErr(err)
+	}
+	// Executables run through the API cannot prompt for input or display the terminal UI.
+	_ = os.Setenv("DISABLE_FLOW_INTERACTIVE", "true")
+
+	address := flags.ValueFor[string](ctx, cmd, *flags.AddressFlag, false)
+	if address == "" {
+		address = ctx.Config.ServerAddress()
+	}
+	token := os.Getenv(ServeTokenEnv)
+	// The token is not passed on to the executables that are run.
+	_ = os.Unsetenv(ServeTokenEnv)
+	if token == "" {
+		tokenBytes := make([]byte, 24)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			logger.FatalErr(err)
+		}
+		token = hex.EncodeToString(tokenBytes)
+		logger.PlainTextInfo("Generated API token: " + token)
+	}
+	if err := ctx.ExecutableCache.Update(logger); err != nil {
+		logger.FatalErr(err)
+	}
+
+	srv := server.New(ctx, server.Options{
+		Token:   token,
+		Allowed: ctx.Config.Server,
+		RunsDir: filesystem.ServerRunsDir(),
+		Run:     runRemote,
+	})
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		logger.Infof("stopping server; interrupting runs in progress")
+		shutdownCtx, cancel := stdCtx.WithTimeout(stdCtx.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Infof("listening on http://%s", address)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.FatalErr(err)
+	}
+	srv.Shutdown()
+}
+
+// runRemote runs the executable with the arguments given in the API request.
+func runRemote(ctx stdCtx.Context, e *executable.Executable, args []string, out stdio.Writer) error {
+	return runInSubprocess(ctx, server.CommandArgs(e, args), out)
+}
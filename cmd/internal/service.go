@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/cmd/internal/flags"
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/services/background"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func RegisterServiceCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:     "service",
+		Aliases: []string{"services", "svc"},
+		Short:   "Manage services that were started in the background.",
+		Long: "Manage the services that were started by `service` executables. Services that are started as part " +
+			"of a serial or parallel executable are stopped when it finishes; all other services keep running " +
+			"until they are stopped.",
+		Args: cobra.NoArgs,
+	}
+	registerServiceListCmd(ctx, subCmd)
+	registerServiceStopCmd(ctx, subCmd)
+	registerServiceLogsCmd(ctx, subCmd)
+	registerServiceRunCmd(ctx, subCmd)
+	rootCmd.AddCommand(subCmd)
+}
+
+// registerServiceRunCmd registers the hidden command that the flow process started for a service runs.
+func registerServiceRunCmd(ctx *context.Context, serviceCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:    "run-cmd -- COMMAND",
+		Short:  "Run the command of a service that was started in the background.",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := background.RunCmd(ctx.Ctx, args[0]); err != nil {
+				ctx.Logger.FatalErr(err)
+			}
+		},
+	}
+	serviceCmd.AddCommand(subCmd)
+}
+
+func registerServiceListCmd(ctx *context.Context, serviceCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the services that were started in the background.",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			serviceListFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.OutputFormatFlag)
+	serviceCmd.AddCommand(subCmd)
+}
+
+func serviceListFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
+	logger := ctx.Logger
+	records, err := background.List()
+	if err != nil {
+		logger.FatalErr(err)
+	}
+
+	outputFormat := flags.ValueFor[string](ctx, cmd, *flags.OutputFormatFlag, false)
+	switch strings.ToLower(outputFormat) {
+	case "yaml", "yml":
+		data, err := yaml.Marshal(records)
+		if err != nil {
+			logger.Fatalf("Failed to marshal services - %v", err)
+		}
+		logger.Println(string(data))
+	case "json":
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			logger.Fatalf("Failed to marshal services - %v", err)
+		}
+		logger.Println(string(data))
+	case "":
+		if len(records) == 0 {
+			logger.PlainTextInfo("No services found")
+			return
+		}
+		w := tabwriter.NewWriter(ctx.StdOut(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "SERVICE\tPID\tSTATUS\tSTARTED\tOUTPUT")
+		for _, record := range records {
+			status := "running"
+			if !record.Running() {
+				status = "exited"
+			}
+			_, _ = fmt.Fprintf(
+				w, "%s\t%d\t%s\t%s\t%s\n",
+				record.Ref, record.PID, status, record.StartedAt.Local().Format(time.RFC822), record.LogFile,
+			)
+		}
+		_ = w.Flush()
+	default:
+		logger.Fatalf("Unsupported output format %s", outputFormat)
+	}
+}
+
+func registerServiceStopCmd(ctx *context.Context, serviceCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "stop [SERVICE]",
+		Short: "Stop a service that was started in the background.",
+		Long: "Stop a service by its executable reference (e.g. `start ws/ns:db`) or ID (e.g. `ws/ns:db` or `db`). " +
+			"The service is sent a termination signal and is killed if it has not exited after its `stopTimeout`.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			serviceStopFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.StopAllFlag)
+	serviceCmd.AddCommand(subCmd)
+}
+
+func serviceStopFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	logger := ctx.Logger
+	records, err := background.List()
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	all := flags.ValueFor[bool](ctx, cmd, *flags.StopAllFlag, false)
+	switch {
+	case all && len(args) > 0:
+		logger.Fatalf("A service cannot be specified when using the --all flag")
+	case !all && len(args) == 0:
+		logger.Fatalf("A service must be specified or the --all flag must be used")
+	case !all:
+		record, err := findService(ctx, records, args[0])
+		if err != nil {
+			logger.FatalErr(err)
+		}
+		records = []*background.Record{record}
+	}
+
+	for _, record := range records {
+		if err := background.Stop(record); err != nil {
+			logger.FatalErr(err)
+		}
+		logger.PlainTextSuccess(fmt.Sprintf("Stopped %s", record.Ref))
+	}
+}
+
+func registerServiceLogsCmd(ctx *context.Context, serviceCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "logs SERVICE",
+		Short: "Print the output of a service that was started in the background.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			serviceLogsFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.FollowFlag)
+	serviceCmd.AddCommand(subCmd)
+}
+
+func serviceLogsFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	logger := ctx.Logger
+	records, err := background.List()
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	record, err := findService(ctx, records, args[0])
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	file, err := os.Open(record.LogFile)
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(ctx.StdOut(), file); err != nil {
+		logger.FatalErr(err)
+	}
+	if !flags.ValueFor[bool](ctx, cmd, *flags.FollowFlag, false) {
+		return
+	}
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for running := true; running; {
+		select {
+		case <-ctx.Ctx.Done():
+			return
+		case <-ticker.C:
+			// The output is read once more after the service exits so that its last lines are printed.
+			running = record.Running()
+			if _, err := io.Copy(ctx.StdOut(), file); err != nil {
+				logger.FatalErr(err)
+			}
+		}
+	}
+}
+
+// findService returns the record of the service that matches the executable reference or ID.
+func findService(ctx *context.Context, records []*background.Record, arg string) (*background.Record, error) {
+	var matches []*background.Record
+	if verb, id, found := strings.Cut(arg, " "); found {
+		ref := context.ExpandRef(ctx, executable.NewRef(id, executable.Verb(verb)))
+		for _, record := range records {
+			if executable.Ref(record.Ref).Equals(ref) {
+				matches = append(matches, record)
+			}
+		}
+	} else {
+		// The verb is only needed to expand the ID with the current workspace and namespace.
+		id := context.ExpandRef(ctx, executable.NewRef(arg, executable.VerbExec)).ID()
+		for _, record := range records {
+			if executable.Ref(record.Ref).ID() == id {
+				matches = append(matches, record)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("service %s not found", arg)
+	case 1:
+		return matches[0], nil
+	default:
+		refs := make([]string, 0, len(matches))
+		for _, record := range matches {
+			refs = append(refs, record.Ref)
+		}
+		return nil, fmt.Errorf("%s matches more than one service: %s", arg, strings.Join(refs, ", "))
+	}
+}
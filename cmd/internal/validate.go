@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jahvon/flow/cmd/internal/flags"
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/internal/validation"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func registerValidateWsCmd(ctx *context.Context, wsCmd *cobra.Command) {
+	validateCmd := &cobra.Command{
+		Use:   "validate [workspace|path]",
+		Short: "Check flowfiles for errors before running them.",
+		Long: "Validate the flowfiles of a workspace, a directory, or a single flowfile. The current workspace is " +
+			"validated if no argument is given.\n\n" +
+			"Each executable is validated and checked for duplicate IDs and alias collisions. Refs in serial and " +
+			"parallel executables must resolve and must not form a cycle, `if` expressions must compile, `file` and " +
+			"`templateFile` paths must exist, and params cannot use the reserved FLOW_ env key prefix. " +
+			"The command exits with a non-zero code when an error is found.",
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			printContext(ctx, cmd)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			validateFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, validateCmd, *flags.ValidateOutputFormatFlag)
+	wsCmd.AddCommand(validateCmd)
+}
+
+func validateFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	logger := ctx.Logger
+	var target string
+	if len(args) > 0 {
+		target = args[0]
+	}
+	wsName, wsPath, paths, err := validationTarget(ctx, target)
+	if err != nil {
+		logger.FatalErr(err)
+	}
+	report := validation.Validate(ctx, wsName, wsPath, paths)
+
+	outputFormat := flags.ValueFor[string](ctx, cmd, *flags.ValidateOutputFormatFlag, false)
+	switch strings.ToLower(outputFormat) {
+	case "yaml", "yml":
+		str, err := report.YAML()
+		if err != nil {
+			logger.Fatalf("Failed to marshal validation report - %v", err)
+		}
+		logger.Println(str)
+	case "json":
+		str, err := report.JSON()
+		if err != nil {
+			logger.Fatalf("Failed to marshal validation report - %v", err)
+		}
+		logger.Println(str)
+	case "":
+		printValidationReport(ctx, report)
+	default:
+		logger.Fatalf("Unsupported output format %s", outputFormat)
+	}
+
+	if report.Errors() > 0 {
+		if outputFormat != "" {
+			// Keep the structured output parsable; the exit code reports the failure.
+			ctx.Finalize()
+			os.Exit(1)
+		}
+		logger.Fatalf("validation failed with %d error(s)", report.Errors())
+	}
+}
+
+func printValidationReport(ctx *context.Context, report *validation.Report) {
+	for _, issue := range report.Issues {
+		msg := issue.File + ": "
+		if issue.Executable != "" {
+			msg += issue.Executable + ": "
+		}
+		msg += issue.Message
+		if issue.Severity == validation.SeverityError {
+			ctx.Logger.PlainTextError(msg)
+		} else {
+			ctx.Logger.PlainTextWarn(msg)
+		}
+	}
+	if report.Errors() == 0 {
+		ctx.Logger.PlainTextSuccess(fmt.Sprintf(
+			"Validated %d executables in %d flowfiles", report.Executables, report.FlowFiles,
+		))
+	}
+}
+
+// validationTarget returns the workspace and flowfile paths to validate. The target is either the name of a
+// registered workspace or the path to a directory or flowfile.
+func validationTarget(ctx *context.Context, target string) (string, string, []string, error) {
+	if _, found := ctx.Config.Workspaces[target]; found || target == "" {
+		ws := workspaceOrCurrent(ctx, target)
+		if ws == nil {
+			return "", "", nil, fmt.Errorf("workspace %s not found", target)
+		}
+		paths, err := filesystem.FindFlowFiles(ctx.Logger, ws)
+		return ws.AssignedName(), ws.Location(), paths, err
+	}
+
+	path, err := filepath.Abs(target)
+	if err != nil {
+		return "", "", nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%s is not a workspace or path: %w", target, err)
+	}
+	var wsName, wsPath string
+	for name, p := range ctx.Config.Workspaces {
+		if (path == p || strings.HasPrefix(path, p+string(filepath.Separator))) && len(p) > len(wsPath) {
+			wsName, wsPath = name, p
+		}
+	}
+	if wsName != "" && info.IsDir() {
+		// The directory is validated with the included and excluded paths of its workspace.
+		ws := workspaceOrCurrent(ctx, wsName)
+		if ws == nil {
+			return "", "", nil, fmt.Errorf("workspace %s not found", wsName)
+		}
+		wsPaths, err := filesystem.FindFlowFiles(ctx.Logger, ws)
+		if err != nil {
+			return "", "", nil, err
+		}
+		paths := make([]string, 0, len(wsPaths))
+		for _, p := range wsPaths {
+			if path == wsPath || strings.HasPrefix(p, path+string(filepath.Separator)) {
+				paths = append(paths, p)
+			}
+		}
+		return wsName, wsPath, paths, nil
+	}
+	// Paths outside of all workspaces are validated as part of the current workspace.
+	if wsName == "" {
+		wsName, wsPath = ctx.CurrentWorkspace.AssignedName(), ctx.CurrentWorkspace.Location()
+	}
+	if !info.IsDir() {
+		return wsName, wsPath, []string{path}, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(path, func(p string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == executable.FlowFileExt {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	return wsName, wsPath, paths, err
+}
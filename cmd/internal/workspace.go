@@ -35,6 +35,7 @@ func RegisterWorkspaceCmd(ctx *context.Context, rootCmd *cobra.Command) {
 	registerDeleteWsCmd(ctx, wsCmd)
 	registerListWorkspaceCmd(ctx, wsCmd)
 	registerViewWsCmd(ctx, wsCmd)
+	registerValidateWsCmd(ctx, wsCmd)
 	rootCmd.AddCommand(wsCmd)
 }
 
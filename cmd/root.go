@@ -73,4 +73,9 @@ func RegisterSubCommands(ctx *context.Context, rootCmd *cobra.Command) {
 	internal.RegisterLogsCmd(ctx, rootCmd)
 	internal.RegisterStoreCmd(ctx, rootCmd)
 	internal.RegisterSyncCmd(ctx, rootCmd)
+	internal.RegisterDaemonCmd(ctx, rootCmd)
+	internal.RegisterServiceCmd(ctx, rootCmd)
+	internal.RegisterServeCmd(ctx, rootCmd)
+	internal.RegisterLSPCmd(ctx, rootCmd)
+	internal.RegisterGraphCmd(ctx, rootCmd)
 }
@@ -149,8 +149,12 @@ func (c *ExecutableCacheImpl) GetExecutableByRef(logger io.Logger, ref executabl
 	if !found {
 		return nil, errors.Wrap(err, "unable to find workspace info for config")
 	}
+	if err := filesystem.ResolveFlowFileImports(cfg, cfgPath, wsInfo.WorkspacePath); err != nil {
+		return nil, errors.Wrap(err, "unable to resolve executable config imports")
+	}
 
 	cfg.SetDefaults()
+	cfg.SetWorkspaceParams(workspaceParams(logger, wsInfo))
 	cfg.SetContext(wsInfo.WorkspaceName, wsInfo.WorkspacePath, cfgPath)
 
 	generated, err := generatedExecutables(logger, wsInfo.WorkspaceName, cfg)
@@ -185,6 +189,7 @@ func (c *ExecutableCacheImpl) GetExecutableList(logger io.Logger) (executable.Ex
 	}
 
 	list := make(executable.ExecutableList, 0)
+	wsParams := make(map[string]executable.ParameterList)
 	for cfgPath := range c.Data.ConfigMap {
 		cfg, err := filesystem.LoadFlowFile(cfgPath)
 		if err != nil {
@@ -196,7 +201,17 @@ func (c *ExecutableCacheImpl) GetExecutableList(logger io.Logger) (executable.Ex
 			logger.Errorx("unable to find workspace info for config", "cfgPath", cfgPath)
 			continue
 		}
+		if err := filesystem.ResolveFlowFileImports(cfg, cfgPath, wsInfo.WorkspacePath); err != nil {
+			logger.Errorx("unable to resolve executable config imports", "cfgPath", cfgPath, "err", err)
+			continue
+		}
 		cfg.SetDefaults()
+		params, found := wsParams[wsInfo.WorkspaceName]
+		if !found {
+			params = workspaceParams(logger, wsInfo)
+			wsParams[wsInfo.WorkspaceName] = params
+		}
+		cfg.SetWorkspaceParams(params)
 		cfg.SetContext(wsInfo.WorkspaceName, wsInfo.WorkspacePath, cfgPath)
 
 		generated, err := generatedExecutables(logger, wsInfo.WorkspaceName, cfg)
@@ -214,6 +229,17 @@ func (c *ExecutableCacheImpl) GetExecutableList(logger io.Logger) (executable.Ex
 	return list, nil
 }
 
+// workspaceParams returns the params that the executables of the workspace inherit. A workspace config that cannot be
+// loaded is logged and treated as having no params.
+func workspaceParams(logger io.Logger, wsInfo WorkspaceInfo) executable.ParameterList {
+	wsCfg, err := filesystem.LoadWorkspaceConfig(wsInfo.WorkspaceName, wsInfo.WorkspacePath)
+	if err != nil {
+		logger.Warnx("unable to load workspace config", "workspace", wsInfo.WorkspaceName, "err", err)
+		return nil
+	}
+	return wsCfg.InheritedParams()
+}
+
 func (c *ExecutableCacheImpl) initExecutableCacheData(logger io.Logger) error {
 	cacheData, err := filesystem.LoadLatestCachedData(execCacheKey)
 	if err != nil {
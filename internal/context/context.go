@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -140,6 +141,13 @@ func (ctx *Context) SetView(view tuikit.View) error {
 	return ctx.TUIContainer.SetView(view)
 }
 
+// ShowTUI returns true if the interactive UI is enabled in the config and has not been disabled with the
+// DISABLE_FLOW_INTERACTIVE environment variable.
+func (ctx *Context) ShowTUI() bool {
+	disabled, _ := strconv.ParseBool(os.Getenv("DISABLE_FLOW_INTERACTIVE"))
+	return !disabled && ctx.Config.ShowTUI()
+}
+
 func (ctx *Context) Finalize() {
 	_ = ctx.stdIn.Close()
 	_ = ctx.stdOut.Close()
@@ -169,10 +177,16 @@ func (ctx *Context) Finalize() {
 }
 
 func ExpandRef(ctx *Context, ref executable.Ref) executable.Ref {
+	return ExpandRefInWorkspace(ctx, ref, ctx.CurrentWorkspace.AssignedName())
+}
+
+// ExpandRefInWorkspace expands the ref like ExpandRef but uses the given workspace, instead of the current one, when
+// the ref does not include a workspace.
+func ExpandRefInWorkspace(ctx *Context, ref executable.Ref, workspace string) executable.Ref {
 	id := ref.ID()
 	ws, ns, name := executable.ParseExecutableID(id)
 	if ws == "" {
-		ws = ctx.CurrentWorkspace.AssignedName()
+		ws = workspace
 	}
 	if ns == "" {
 		ns = ctx.Config.CurrentNamespace
@@ -0,0 +1,311 @@
+package daemon
+
+import (
+	stdCtx "context"
+	"fmt"
+	stdio "io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/services/cron"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	// reloadInterval is the longest the scheduler waits before reloading the scheduled executables.
+	reloadInterval = time.Minute
+	// maxLogFiles is the number of run log files kept for each executable.
+	maxLogFiles = 20
+)
+
+// RunFunc runs a scheduled executable and writes its output to out. The run should stop when the context is
+// cancelled.
+type RunFunc func(ctx stdCtx.Context, e *executable.Executable, out stdio.Writer) error
+
+// LoadFunc returns the executables that may be scheduled.
+type LoadFunc func() (executable.ExecutableList, error)
+
+type job struct {
+	exec     *executable.Executable
+	schedule *cron.Schedule
+	next     time.Time
+	running  bool
+}
+
+// Scheduler runs executables that have a schedule when they are due. Each executable runs at most once at a time;
+// if a run is still in progress when the executable is due again, the new run is skipped.
+type Scheduler struct {
+	ctx   *context.Context
+	clock Clock
+	run   RunFunc
+	dir   string
+
+	mu    sync.Mutex
+	state *State
+	jobs  map[string]*job
+	wg    sync.WaitGroup
+}
+
+// NewScheduler returns a scheduler that stores its state and run logs in the given directory.
+func NewScheduler(ctx *context.Context, clock Clock, run RunFunc, dir string) (*Scheduler, error) {
+	state, err := LoadState(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheduler{
+		ctx:   ctx,
+		clock: clock,
+		run:   run,
+		dir:   dir,
+		state: state,
+		jobs:  make(map[string]*job),
+	}, nil
+}
+
+// State returns a copy of the scheduler's state.
+func (s *Scheduler) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := *s.state
+	state.Executables = make(map[string]*ExecutableState, len(s.state.Executables))
+	for ref, execState := range s.state.Executables {
+		copied := *execState
+		if execState.LastRun != nil {
+			lastRun := *execState.LastRun
+			copied.LastRun = &lastRun
+		}
+		state.Executables[ref] = &copied
+	}
+	return state
+}
+
+// Load updates the scheduled executables. A scheduled time that was missed since the schedule was last handled is
+// due immediately so that it is caught up once, no matter how many times it was missed.
+func (s *Scheduler) Load(execs executable.ExecutableList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	found := make(map[string]bool)
+	for _, e := range execs {
+		if e.Schedule == "" {
+			continue
+		}
+		ref := e.Ref().String()
+		schedule, err := cron.Parse(e.Schedule)
+		if err != nil {
+			s.ctx.Logger.Errorf("unable to schedule %s: %v", ref, err)
+			continue
+		}
+		found[ref] = true
+		if j, exists := s.jobs[ref]; exists && j.schedule.String() == schedule.String() {
+			j.exec = e
+			continue
+		}
+
+		j := &job{exec: e, schedule: schedule, next: schedule.Next(now)}
+		if existing, exists := s.jobs[ref]; exists {
+			j.running = existing.running
+		}
+		execState := s.state.Executables[ref]
+		if execState == nil {
+			execState = &ExecutableState{}
+			s.state.Executables[ref] = execState
+		}
+		if execState.Schedule == e.Schedule && !execState.LastScheduled.IsZero() {
+			if missed := schedule.Next(execState.LastScheduled); !missed.IsZero() && !missed.After(now) {
+				j.next = missed
+			}
+		} else {
+			execState.Schedule = e.Schedule
+			execState.LastScheduled = now
+		}
+		execState.NextRun = j.next
+		s.jobs[ref] = j
+		s.ctx.Logger.Debugf("scheduled %s (%s); next run at %s", ref, e.Schedule, j.next.Format(time.RFC3339))
+	}
+	for ref := range s.jobs {
+		if !found[ref] {
+			s.ctx.Logger.Debugf("unscheduled %s", ref)
+			delete(s.jobs, ref)
+		}
+	}
+	s.saveState()
+}
+
+// RunDue starts the runs of the executables that are due and returns their references.
+func (s *Scheduler) RunDue() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	refs := make([]string, 0, len(s.jobs))
+	for ref := range s.jobs {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+
+	var started []string
+	for _, ref := range refs {
+		j := s.jobs[ref]
+		if j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+		scheduled := j.next
+		j.next = j.schedule.Next(now)
+		execState := s.state.Executables[ref]
+		execState.LastScheduled = scheduled
+		execState.NextRun = j.next
+		if j.running {
+			execState.Skipped++
+			s.ctx.Logger.Warnf("skipping scheduled run of %s; the previous run is still in progress", ref)
+			continue
+		}
+
+		record := &RunRecord{
+			Status:    RunStatusRunning,
+			Scheduled: scheduled,
+			Start:     now,
+			// A run that is more than a minute late was missed while the daemon was stopped.
+			CatchUp: now.Sub(scheduled) >= time.Minute,
+		}
+		logFile, err := s.newLogFile(ref, now)
+		if err != nil {
+			s.ctx.Logger.Errorf("unable to create log file for %s: %v", ref, err)
+		} else {
+			record.LogFile = logFile.Name()
+		}
+		execState.LastRun = record
+		j.running = true
+		started = append(started, ref)
+		s.wg.Add(1)
+		go s.execute(ref, j, j.exec, record, logFile)
+	}
+	s.saveState()
+	return started
+}
+
+// NextDue returns the earliest time that an executable is due. A zero time is returned if nothing is scheduled.
+func (s *Scheduler) NextDue() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && (next.IsZero() || j.next.Before(next)) {
+			next = j.next
+		}
+	}
+	return next
+}
+
+// Wait blocks until all runs that were started have completed.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Run loads the scheduled executables and runs them as they become due until the context is cancelled. The
+// executables are reloaded periodically so that schedule changes are picked up without restarting.
+func (s *Scheduler) Run(load LoadFunc) error {
+	s.mu.Lock()
+	if s.state.PID != 0 && s.state.PID != os.Getpid() && s.state.Running() {
+		s.mu.Unlock()
+		return fmt.Errorf("daemon is already running (pid %d)", s.state.PID)
+	}
+	s.state.PID = os.Getpid()
+	s.state.StartedAt = s.clock.Now()
+	s.saveState()
+	s.mu.Unlock()
+
+	defer func() {
+		s.Wait()
+		s.mu.Lock()
+		s.state.PID = 0
+		s.saveState()
+		s.mu.Unlock()
+	}()
+
+	for {
+		execs, err := load()
+		if err != nil {
+			s.ctx.Logger.Errorf("unable to load executables: %v", err)
+		} else {
+			s.Load(execs)
+		}
+		s.RunDue()
+
+		wait := reloadInterval
+		if next := s.NextDue(); !next.IsZero() {
+			wait = min(wait, max(next.Sub(s.clock.Now()), 0))
+		}
+		select {
+		case <-s.ctx.Ctx.Done():
+			return nil
+		case <-s.clock.After(wait):
+		}
+	}
+}
+
+// execute runs the executable and records the result. The log file is nil if it could not be created.
+func (s *Scheduler) execute(ref string, j *job, e *executable.Executable, record *RunRecord, logFile *os.File) {
+	defer s.wg.Done()
+	s.ctx.Logger.Infof("running %s (scheduled for %s)", ref, record.Scheduled.Format(time.RFC3339))
+
+	var out stdio.Writer = stdio.Discard
+	if logFile != nil {
+		out = logFile
+	}
+	runErr := s.run(s.ctx.Ctx, e, out)
+	if logFile != nil {
+		if runErr != nil {
+			_, _ = fmt.Fprintf(logFile, "\nrun failed: %v\n", runErr)
+		}
+		_ = logFile.Close()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.running = false
+	record.End = s.clock.Now()
+	execState := s.state.Executables[ref]
+	execState.Runs++
+	if runErr != nil {
+		execState.Failures++
+		record.Status = RunStatusFailed
+		record.Error = runErr.Error()
+		s.ctx.Logger.Errorf("scheduled run of %s failed: %v", ref, runErr)
+	} else {
+		record.Status = RunStatusSucceeded
+		s.ctx.Logger.Infof("scheduled run of %s succeeded", ref)
+	}
+	s.saveState()
+}
+
+// newLogFile creates the log file for a run and removes the oldest log files of the executable.
+func (s *Scheduler) newLogFile(ref string, start time.Time) (*os.File, error) {
+	replacer := strings.NewReplacer(" ", "_", "/", "_", ":", "_")
+	dir := filepath.Join(s.dir, "logs", replacer.Replace(ref))
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) >= maxLogFiles {
+		// The file names start with the run time so the oldest files are listed first.
+		for _, entry := range entries[:len(entries)-maxLogFiles+1] {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	name := start.Format("20060102T150405") + ".log"
+	return os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}
+
+// saveState saves the state and logs any error since the state is only needed to catch up on missed runs and to
+// report the status. The caller must hold the lock.
+func (s *Scheduler) saveState() {
+	if err := s.state.Save(s.dir); err != nil {
+		s.ctx.Logger.Errorf("unable to save daemon state: %v", err)
+	}
+}
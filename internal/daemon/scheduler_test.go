@@ -0,0 +1,192 @@
+package daemon_test
+
+import (
+	stdCtx "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/daemon"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestDaemon(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Daemon Suite")
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+type fakeRunner struct {
+	mu      sync.Mutex
+	runs    []string
+	err     error
+	release chan struct{}
+}
+
+func (r *fakeRunner) run(_ stdCtx.Context, e *executable.Executable, out io.Writer) error {
+	r.mu.Lock()
+	r.runs = append(r.runs, e.Ref().String())
+	r.mu.Unlock()
+	_, _ = fmt.Fprintln(out, "running "+e.Name)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.err
+}
+
+func (r *fakeRunner) Runs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.runs...)
+}
+
+var _ = Describe("Scheduler", func() {
+	var (
+		ctx    *context.Context
+		clock  *fakeClock
+		runner *fakeRunner
+		dir    string
+		execs  executable.ExecutableList
+		ref    = "exec ws/ns:report"
+	)
+
+	// Wednesday, January 15th 2025 at 8:30am
+	start := time.Date(2025, time.January, 15, 8, 30, 0, 0, time.Local)
+	nineAM := func(day int) time.Time {
+		return time.Date(2025, time.January, day, 9, 0, 0, 0, time.Local)
+	}
+
+	newScheduler := func() *daemon.Scheduler {
+		s, err := daemon.NewScheduler(ctx, clock, runner.run, dir)
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContext(stdCtx.Background(), GinkgoT())
+		clock = &fakeClock{now: start}
+		runner = &fakeRunner{}
+		dir = GinkgoT().TempDir()
+		e := &executable.Executable{Verb: "exec", Name: "report", Schedule: "0 9 * * *"}
+		e.SetContext("ws", "/ws", "ns", "/ws/report.flow")
+		unscheduled := &executable.Executable{Verb: "exec", Name: "other"}
+		unscheduled.SetContext("ws", "/ws", "ns", "/ws/report.flow")
+		execs = executable.ExecutableList{e, unscheduled}
+	})
+
+	It("should run executables when they are due", func() {
+		s := newScheduler()
+		s.Load(execs)
+		Expect(s.NextDue()).To(BeTemporally("==", nineAM(15)))
+		Expect(s.RunDue()).To(BeEmpty())
+
+		clock.Set(start.Add(30 * time.Minute))
+		Expect(s.RunDue()).To(ConsistOf(ref))
+		s.Wait()
+		Expect(runner.Runs()).To(ConsistOf(ref))
+
+		state := s.State()
+		Expect(state.Executables).To(HaveKey(ref))
+		Expect(state.Executables[ref].Runs).To(Equal(1))
+		Expect(state.Executables[ref].NextRun).To(BeTemporally("==", nineAM(16)))
+		lastRun := state.Executables[ref].LastRun
+		Expect(lastRun.Status).To(Equal(daemon.RunStatusSucceeded))
+		Expect(lastRun.CatchUp).To(BeFalse())
+		logData, err := os.ReadFile(lastRun.LogFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(logData)).To(ContainSubstring("running report"))
+	})
+
+	It("should record failed runs", func() {
+		runner.err = errors.New("report failed")
+		s := newScheduler()
+		s.Load(execs)
+		clock.Set(start.Add(30 * time.Minute))
+		s.RunDue()
+		s.Wait()
+
+		state := s.State()
+		Expect(state.Executables[ref].Failures).To(Equal(1))
+		Expect(state.Executables[ref].LastRun.Status).To(Equal(daemon.RunStatusFailed))
+		Expect(state.Executables[ref].LastRun.Error).To(Equal("report failed"))
+	})
+
+	It("should skip runs while the previous run is in progress", func() {
+		execs[0].Schedule = "* * * * *"
+		runner.release = make(chan struct{})
+		s := newScheduler()
+		s.Load(execs)
+
+		clock.Set(start.Add(time.Minute))
+		Expect(s.RunDue()).To(ConsistOf(ref))
+		clock.Set(start.Add(2 * time.Minute))
+		Expect(s.RunDue()).To(BeEmpty())
+		close(runner.release)
+		s.Wait()
+
+		clock.Set(start.Add(3 * time.Minute))
+		Expect(s.RunDue()).To(ConsistOf(ref))
+		s.Wait()
+		Expect(runner.Runs()).To(HaveLen(2))
+		Expect(s.State().Executables[ref].Skipped).To(Equal(1))
+	})
+
+	It("should catch up a missed run once after a restart", func() {
+		s := newScheduler()
+		s.Load(execs)
+
+		// The daemon is restarted two days later, after missing two scheduled runs.
+		clock.Set(start.Add(48 * time.Hour))
+		restarted := newScheduler()
+		restarted.Load(execs)
+		Expect(restarted.RunDue()).To(ConsistOf(ref))
+		restarted.Wait()
+		Expect(restarted.RunDue()).To(BeEmpty())
+
+		state := restarted.State()
+		Expect(runner.Runs()).To(HaveLen(1))
+		Expect(state.Executables[ref].LastRun.CatchUp).To(BeTrue())
+		Expect(state.Executables[ref].LastRun.Scheduled).To(BeTemporally("==", nineAM(15)))
+		Expect(state.Executables[ref].NextRun).To(BeTemporally("==", nineAM(17)))
+	})
+
+	It("should not catch up runs for a new schedule", func() {
+		s := newScheduler()
+		s.Load(execs)
+
+		clock.Set(start.Add(48 * time.Hour))
+		execs[0].Schedule = "0 10 * * *"
+		restarted := newScheduler()
+		restarted.Load(execs)
+		Expect(restarted.RunDue()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const stateFileName = "state.yaml"
+
+type RunStatus string
+
+const (
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+	RunStatusRunning   RunStatus = "running"
+)
+
+// State is the daemon's record of the scheduled executables and their runs. It is saved after each change so that
+// missed runs can be caught up when the daemon is restarted.
+type State struct {
+	// PID is the process ID of the running daemon. It is zero when the daemon is stopped.
+	PID       int       `yaml:"pid,omitempty"`
+	StartedAt time.Time `yaml:"startedAt,omitempty"`
+	// Executables is a map of executable reference to the state of its schedule.
+	Executables map[string]*ExecutableState `yaml:"executables"`
+}
+
+type ExecutableState struct {
+	Schedule string `yaml:"schedule"`
+	// LastScheduled is the latest scheduled time that was handled, whether the executable ran or was skipped.
+	LastScheduled time.Time  `yaml:"lastScheduled"`
+	NextRun       time.Time  `yaml:"nextRun,omitempty"`
+	LastRun       *RunRecord `yaml:"lastRun,omitempty"`
+	Runs          int        `yaml:"runs"`
+	Failures      int        `yaml:"failures"`
+	Skipped       int        `yaml:"skipped"`
+}
+
+type RunRecord struct {
+	Status    RunStatus `yaml:"status"`
+	Scheduled time.Time `yaml:"scheduled"`
+	Start     time.Time `yaml:"start"`
+	End       time.Time `yaml:"end,omitempty"`
+	Error     string    `yaml:"error,omitempty"`
+	LogFile   string    `yaml:"logFile,omitempty"`
+	// CatchUp is true when the run was for a scheduled time that was missed while the daemon was stopped.
+	CatchUp bool `yaml:"catchUp,omitempty"`
+}
+
+func StatePath(dir string) string {
+	return filepath.Join(dir, stateFileName)
+}
+
+// LoadState reads the daemon state from the directory. An empty state is returned if it has not been saved yet.
+func LoadState(dir string) (*State, error) {
+	state := &State{Executables: make(map[string]*ExecutableState)}
+	data, err := os.ReadFile(filepath.Clean(StatePath(dir)))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "unable to read daemon state")
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrap(err, "unable to parse daemon state")
+	}
+	if state.Executables == nil {
+		state.Executables = make(map[string]*ExecutableState)
+	}
+	return state, nil
+}
+
+func (s *State) Save(dir string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal daemon state")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(err, "unable to create daemon directory")
+	}
+	// The state is written to a temporary file first so that it is never partially written.
+	tmpPath := StatePath(dir) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return errors.Wrap(err, "unable to write daemon state")
+	}
+	return errors.Wrap(os.Rename(tmpPath, StatePath(dir)), "unable to write daemon state")
+}
+
+// Running returns true if the daemon process recorded in the state is still running.
+func (s *State) Running() bool {
+	if s.PID == 0 {
+		return false
+	}
+	proc, err := os.FindProcess(s.PID)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// FindProcess only succeeds on Windows if the process exists.
+		return true
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
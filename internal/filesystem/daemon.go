@@ -0,0 +1,27 @@
+package filesystem
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func DaemonDir() string {
+	return CachedDataDirPath() + "/daemon"
+}
+
+func DaemonLogsDir() string {
+	return DaemonDir() + "/logs"
+}
+
+func EnsureDaemonDir() error {
+	if _, err := os.Stat(DaemonLogsDir()); os.IsNotExist(err) {
+		err = os.MkdirAll(DaemonLogsDir(), 0750)
+		if err != nil {
+			return errors.Wrap(err, "unable to create daemon directory")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "unable to check for daemon directory")
+	}
+	return nil
+}
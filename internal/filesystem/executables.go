@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/jahvon/tuikit/io"
@@ -64,7 +65,7 @@ func LoadWorkspaceFlowFiles(
 	logger io.Logger,
 	workspaceCfg *workspace.Workspace,
 ) (executable.FlowFileList, error) {
-	cfgFiles, err := findFlowFiles(logger, workspaceCfg)
+	cfgFiles, err := FindFlowFiles(logger, workspaceCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +77,12 @@ func LoadWorkspaceFlowFiles(
 			logger.Errorx("unable to load executable config file", "configFile", cfgFile, "err", err)
 			continue
 		}
+		if err := ResolveFlowFileImports(cfg, cfgFile, workspaceCfg.Location()); err != nil {
+			logger.Errorx("unable to resolve executable config file imports", "configFile", cfgFile, "err", err)
+			continue
+		}
 		cfg.SetDefaults()
+		cfg.SetWorkspaceParams(workspaceCfg.InheritedParams())
 		cfg.SetContext(workspaceCfg.AssignedName(), workspaceCfg.Location(), cfgFile)
 		cfgs = append(cfgs, cfg)
 	}
@@ -89,7 +95,8 @@ func LoadWorkspaceFlowFiles(
 	return cfgs, nil
 }
 
-func findFlowFiles(logger io.Logger, workspaceCfg *workspace.Workspace) ([]string, error) {
+// FindFlowFiles returns the paths of the flowfiles that are included in the workspace.
+func FindFlowFiles(logger io.Logger, workspaceCfg *workspace.Workspace) ([]string, error) {
 	var includePaths, excludedPaths []string
 	if workspaceCfg.Executables != nil {
 		includePaths = workspaceCfg.Executables.Included
@@ -112,7 +119,7 @@ func findFlowFiles(logger io.Logger, workspaceCfg *workspace.Workspace) ([]strin
 			return err
 		}
 		if isPathIncluded(logger, path, workspaceCfg.Location(), includePaths) {
-			if isPathExcluded(logger, path, workspaceCfg.Location(), excludedPaths) {
+			if IsPathExcluded(logger, path, workspaceCfg.Location(), excludedPaths) {
 				return filepath.SkipDir
 			}
 
@@ -161,7 +168,7 @@ func isPathIncluded(logger io.Logger, path, basePath string, includePaths []stri
 }
 
 // IsPathExcluded returns true if the path is in any of the excluded paths.
-func isPathExcluded(logger io.Logger, path, basePath string, excludedPaths []string) bool {
+func IsPathExcluded(logger io.Logger, path, basePath string, excludedPaths []string) bool {
 	if excludedPaths == nil {
 		return false
 	}
@@ -190,3 +197,154 @@ func isPathExcluded(logger io.Logger, path, basePath string, excludedPaths []str
 	}
 	return false
 }
+
+// ResolveFlowFileImports adds the executables of the files imported by the flowfile to it and expands the fragments
+// included by its executables. An error is returned when an import cannot be loaded or when an executable or fragment
+// is defined in more than one of the files.
+func ResolveFlowFileImports(cfg *executable.FlowFile, cfgFile, workspacePath string) error {
+	imported, err := resolveImports(cfg, filepath.Clean(cfgFile), workspacePath, nil)
+	if err != nil {
+		return err
+	}
+	cfg.Executables = imported.executables
+	return nil
+}
+
+// importedFile holds the executables and fragments of a flowfile and its imports, along with the file that defines
+// each of them so that the same file imported through different paths is not reported as a conflict.
+type importedFile struct {
+	executables     executable.ExecutableList
+	executableFiles map[string]string
+	fragments       *executable.Fragments
+	fragmentFiles   map[string]string
+}
+
+func resolveImports(cfg *executable.FlowFile, path, workspacePath string, stack []string) (*importedFile, error) {
+	stack = append(stack, path)
+	result := &importedFile{
+		executableFiles: make(map[string]string),
+		fragments: &executable.Fragments{
+			Params: make(map[string]executable.ParameterList),
+			Args:   make(map[string]executable.ArgumentList),
+			Steps:  make(map[string]executable.SerialRefConfigList),
+		},
+		fragmentFiles: make(map[string]string),
+	}
+	if err := result.addFragments(cfg.Fragments, func(string) string { return path }); err != nil {
+		return nil, err
+	}
+
+	imports := make([]*importedFile, 0, len(cfg.Imports))
+	for _, imp := range cfg.Imports {
+		impPath := importPath(imp, path, workspacePath)
+		if workspacePath != "" && !strings.HasPrefix(impPath, filepath.Clean(workspacePath)+string(filepath.Separator)) {
+			return nil, fmt.Errorf("import %s is outside of the workspace", imp)
+		}
+		// Flowfiles are loaded on their own, so importing one would load its executables twice.
+		if filepath.Ext(impPath) == executable.FlowFileExt {
+			return nil, fmt.Errorf("import %s cannot be a %s file", imp, executable.FlowFileExt)
+		}
+		if slices.Contains(stack, impPath) {
+			return nil, fmt.Errorf("import cycle: %s", strings.Join(append(stack, impPath), " -> "))
+		}
+		impCfg, err := LoadFlowFile(impPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import %s - %w", imp, err)
+		}
+		impFile, err := resolveImports(impCfg, impPath, workspacePath, stack)
+		if err != nil {
+			return nil, err
+		}
+		fileOf := func(key string) string { return impFile.fragmentFiles[key] }
+		if err := result.addFragments(impFile.fragments, fileOf); err != nil {
+			return nil, err
+		}
+		imports = append(imports, impFile)
+	}
+
+	if err := cfg.ExpandFragments(result.fragments); err != nil {
+		return nil, err
+	}
+	for _, e := range cfg.Executables {
+		result.executableFiles[importKey(e)] = path
+		result.executables = append(result.executables, e)
+	}
+	for _, impFile := range imports {
+		for _, e := range impFile.executables {
+			file := impFile.executableFiles[importKey(e)]
+			if other, found := result.executableFiles[importKey(e)]; found {
+				if other == file {
+					continue
+				}
+				return nil, fmt.Errorf("executable %s %s is defined in both %s and %s", e.Verb, e.Name, other, file)
+			}
+			result.executableFiles[importKey(e)] = file
+			result.executables = append(result.executables, e)
+		}
+	}
+	return result, nil
+}
+
+// addFragments adds the fragments to the set. fileOf returns the file that defines the fragment with the key.
+func (f *importedFile) addFragments(fragments *executable.Fragments, fileOf func(key string) string) error {
+	if fragments == nil {
+		return nil
+	}
+	add := func(kind, name string) (bool, error) {
+		key := kind + " " + name
+		file := fileOf(key)
+		if other, found := f.fragmentFiles[key]; found {
+			if other == file {
+				return false, nil
+			}
+			return false, fmt.Errorf("%s fragment %s is defined in both %s and %s", kind, name, other, file)
+		}
+		f.fragmentFiles[key] = file
+		return true, nil
+	}
+	for name, params := range fragments.Params {
+		if ok, err := add("params", name); err != nil {
+			return err
+		} else if ok {
+			f.fragments.Params[name] = params
+		}
+	}
+	for name, args := range fragments.Args {
+		if ok, err := add("args", name); err != nil {
+			return err
+		} else if ok {
+			f.fragments.Args[name] = args
+		}
+	}
+	for name, steps := range fragments.Steps {
+		if ok, err := add("steps", name); err != nil {
+			return err
+		} else if ok {
+			f.fragments.Steps[name] = steps
+		}
+	}
+	return nil
+}
+
+// importPath returns the absolute path of an import. Paths starting with // are relative to the workspace root and
+// other relative paths are relative to the importing file.
+func importPath(imp, path, workspacePath string) string {
+	switch {
+	case strings.HasPrefix(imp, "//"):
+		return filepath.Join(workspacePath, strings.TrimPrefix(imp, "//"))
+	case filepath.IsAbs(imp):
+		return filepath.Clean(imp)
+	default:
+		return filepath.Join(filepath.Dir(path), imp)
+	}
+}
+
+// importKey identifies an executable by its name and the group of its verb, since related verbs can be used
+// interchangeably in refs.
+func importKey(e *executable.Executable) string {
+	group, found := executable.ValidVerbToGroupID[e.Verb]
+	if !found {
+		group = e.Verb.String()
+	}
+	return group + " " + e.Name
+}
@@ -1,6 +1,7 @@
 package filesystem_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -150,5 +151,204 @@ var _ = Describe("Executables", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(definitions).To(BeEmpty())
 		})
+
+		It("does not load flowfiles with conflicting imports", func() {
+			Expect(os.WriteFile(filepath.Join(tmpDir, "shared.yaml"), []byte(`
+executables:
+  - verb: build
+    name: app
+    exec:
+      cmd: make
+`), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "test"+executable.FlowFileExt), []byte(`
+imports: [shared.yaml]
+executables:
+  - verb: build
+    name: app
+    exec:
+      cmd: go build
+`), 0600)).To(Succeed())
+
+			workspaceCfg := &workspace.Workspace{}
+			workspaceCfg.SetContext("test", tmpDir)
+
+			ctrl := gomock.NewController(GinkgoT())
+			logger := mocks.NewMockLogger(ctrl)
+			logger.EXPECT().Debugx(gomock.Any(), gomock.Any()).AnyTimes()
+			logger.EXPECT().Errorx("unable to resolve executable config file imports", gomock.Any()).Times(1)
+
+			definitions, err := filesystem.LoadWorkspaceFlowFiles(logger, workspaceCfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(BeEmpty())
+		})
+	})
+
+	Describe("ResolveFlowFileImports", func() {
+		writeFile := func(name, content string) string {
+			path := filepath.Join(tmpDir, name)
+			Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		resolve := func(path string) (*executable.FlowFile, error) {
+			cfg, err := filesystem.LoadFlowFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			return cfg, filesystem.ResolveFlowFileImports(cfg, path, tmpDir)
+		}
+
+		It("adds imported executables and expands fragments", func() {
+			writeFile("shared/common.yaml", `
+imports: [//shared/base.yaml]
+fragments:
+  params:
+    aws:
+      - secretRef: aws-key
+        envKey: AWS_KEY
+  steps:
+    notify:
+      - cmd: echo notify
+        retries: 1
+executables:
+  - verb: lint
+    name: app
+    exec:
+      cmd: golangci-lint run
+`)
+			writeFile("shared/base.yaml", `
+fragments:
+  args:
+    env:
+      - flag: env
+        envKey: ENV
+        default: dev
+`)
+			path := writeFile("app"+executable.FlowFileExt, `
+imports: [shared/common.yaml, shared/base.yaml]
+executables:
+  - verb: deploy
+    name: app
+    serial:
+      params:
+        - fragment: aws
+        - text: value
+          envKey: OTHER
+      args:
+        - fragment: env
+      execs:
+        - cmd: ./deploy.sh
+        - fragment: notify
+  - verb: test
+    name: app
+    parallel:
+      execs:
+        - fragment: notify
+        - cmd: go test ./...
+`)
+			cfg, err := resolve(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Executables).To(HaveLen(3))
+			Expect(cfg.Executables[2].Name).To(Equal("app"))
+			Expect(cfg.Executables[2].Verb).To(Equal(executable.Verb("lint")))
+
+			deploy := cfg.Executables[0].Serial
+			Expect(deploy.Params).To(Equal(executable.ParameterList{
+				{SecretRef: "aws-key", EnvKey: "AWS_KEY"},
+				{Text: "value", EnvKey: "OTHER"},
+			}))
+			Expect(deploy.Args).To(HaveLen(1))
+			Expect(deploy.Args[0].EnvKey).To(Equal("ENV"))
+			Expect(deploy.Execs).To(Equal(executable.SerialRefConfigList{
+				{Cmd: "./deploy.sh"}, {Cmd: "echo notify", Retries: 1},
+			}))
+			Expect(cfg.Executables[1].Parallel.Execs).To(Equal(executable.ParallelRefConfigList{
+				{Cmd: "echo notify", Retries: 1}, {Cmd: "go test ./..."},
+			}))
+		})
+
+		It("reports fragments and executables defined in more than one file", func() {
+			shared := writeFile("shared.yaml", `
+fragments:
+  params:
+    aws:
+      - text: value
+        envKey: AWS_KEY
+executables:
+  - verb: build
+    name: app
+    exec:
+      cmd: make
+`)
+			path := writeFile("fragment"+executable.FlowFileExt, `
+imports: [shared.yaml]
+fragments:
+  params:
+    aws:
+      - text: other
+        envKey: AWS_KEY
+`)
+			_, err := resolve(path)
+			Expect(err).To(MatchError(ContainSubstring("params fragment aws is defined in both")))
+
+			path = writeFile("executable"+executable.FlowFileExt, `
+imports: [shared.yaml]
+executables:
+  - verb: compile
+    name: app
+    exec:
+      cmd: go build
+`)
+			_, err = resolve(path)
+			Expect(err).To(MatchError(fmt.Sprintf(
+				"executable build app is defined in both %s and %s", path, shared,
+			)))
+		})
+
+		It("reports missing fragments and invalid imports", func() {
+			path := writeFile("missing"+executable.FlowFileExt, `
+executables:
+  - verb: build
+    name: app
+    exec:
+      cmd: make
+      params:
+        - fragment: aws
+`)
+			_, err := resolve(path)
+			Expect(err).To(MatchError("build app - params fragment aws not found"))
+
+			writeFile("a.yaml", "imports: [b.yaml]")
+			writeFile("b.yaml", "imports: [a.yaml]")
+			path = writeFile("cycle"+executable.FlowFileExt, "imports: [a.yaml]")
+			_, err = resolve(path)
+			Expect(err).To(MatchError(ContainSubstring("import cycle")))
+
+			path = writeFile("outside"+executable.FlowFileExt, "imports: [../other.yaml]")
+			_, err = resolve(path)
+			Expect(err).To(MatchError("import ../other.yaml is outside of the workspace"))
+
+			writeFile("shared"+executable.FlowFileExt, "executables: []")
+			path = writeFile("flowfile"+executable.FlowFileExt, "imports: [shared.flow]")
+			_, err = resolve(path)
+			Expect(err).To(MatchError("import shared.flow cannot be a .flow file"))
+		})
+
+		It("reports serial only steps included in parallel execs", func() {
+			path := writeFile("parallel"+executable.FlowFileExt, `
+fragments:
+  steps:
+    review:
+      - cmd: echo review
+        reviewRequired: true
+executables:
+  - verb: build
+    name: app
+    parallel:
+      execs:
+        - fragment: review
+`)
+			_, err := resolve(path)
+			Expect(err).To(MatchError(ContainSubstring("steps fragment review cannot be included in parallel execs")))
+		})
 	})
 })
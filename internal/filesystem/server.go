@@ -0,0 +1,24 @@
+package filesystem
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// ServerRunsDir is the directory that the output of the runs started through `flow serve` is saved in.
+func ServerRunsDir() string {
+	return CachedDataDirPath() + "/server/runs"
+}
+
+func EnsureServerRunsDir() error {
+	if _, err := os.Stat(ServerRunsDir()); os.IsNotExist(err) {
+		err = os.MkdirAll(ServerRunsDir(), 0750)
+		if err != nil {
+			return errors.Wrap(err, "unable to create server runs directory")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "unable to check for server runs directory")
+	}
+	return nil
+}
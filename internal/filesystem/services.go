@@ -0,0 +1,23 @@
+package filesystem
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func ServicesDir() string {
+	return CachedDataDirPath() + "/services"
+}
+
+func EnsureServicesDir() error {
+	if _, err := os.Stat(ServicesDir()); os.IsNotExist(err) {
+		err = os.MkdirAll(ServicesDir(), 0750)
+		if err != nil {
+			return errors.Wrap(err, "unable to create services directory")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "unable to check for services directory")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Package graph builds the dependency graph formed by the refs of serial and parallel executables and renders it as
+// Graphviz DOT, Mermaid, JSON, or a text tree.
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type EdgeType string
+
+const (
+	EdgeTypeSerial   EdgeType = "serial"
+	EdgeTypeParallel EdgeType = "parallel"
+)
+
+// Node is an executable in the graph. Refs that cannot be resolved are added as missing nodes so that broken edges
+// are still shown.
+type Node struct {
+	ID          string `json:"id"`
+	Workspace   string `json:"workspace"`
+	Namespace   string `json:"namespace,omitempty"`
+	Description string `json:"description,omitempty"`
+	Missing     bool   `json:"missing,omitempty"`
+}
+
+// Edge is a ref from a step of a serial or parallel executable. Step starts at 1 and follows the order of the execs.
+type Edge struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Type      EdgeType `json:"type"`
+	Step      int      `json:"step"`
+	Condition string   `json:"condition,omitempty"`
+	Retries   int      `json:"retries,omitempty"`
+}
+
+type Graph struct {
+	Roots []string `json:"roots"`
+	Nodes []*Node  `json:"nodes"`
+	Edges []*Edge  `json:"edges"`
+
+	nodes map[string]*Node
+}
+
+func (g *Graph) JSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal graph - %w", err)
+	}
+	return string(jsonBytes), nil
+}
+
+// Node returns the node with the ID, or nil if it is not in the graph.
+func (g *Graph) Node(id string) *Node {
+	return g.nodes[id]
+}
+
+// Children returns the edges that start at the node, in step order.
+func (g *Graph) Children(id string) []*Edge {
+	var edges []*Edge
+	for _, e := range g.Edges {
+		if e.From == id {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+type builder struct {
+	ctx   *context.Context
+	graph *Graph
+}
+
+// ForExecutable builds the graph of the executables reachable from the executable.
+func ForExecutable(ctx *context.Context, e *executable.Executable) *Graph {
+	b := newBuilder(ctx)
+	b.visit(e)
+	b.graph.Roots = []string{e.Ref().String()}
+	return b.graph
+}
+
+// ForWorkspace builds the graph of the executables in the workspace that reference, or are referenced by, another
+// executable. Edges are followed into other workspaces. The roots are the executables of the workspace that are not
+// referenced by another executable of the workspace.
+func ForWorkspace(ctx *context.Context, execs executable.ExecutableList) *Graph {
+	b := newBuilder(ctx)
+	inWorkspace := make(map[string]bool)
+	for _, e := range execs {
+		if len(e.Steps()) > 0 {
+			b.visit(e)
+			inWorkspace[e.Ref().String()] = true
+		}
+	}
+
+	referenced := make(map[string]bool)
+	for _, edge := range b.graph.Edges {
+		if inWorkspace[edge.From] {
+			referenced[edge.To] = true
+		}
+	}
+	for _, e := range execs {
+		id := e.Ref().String()
+		if inWorkspace[id] && !referenced[id] {
+			b.graph.Roots = append(b.graph.Roots, id)
+		}
+	}
+	// Executables that are only part of cycles are not reachable from a root, so the first one found becomes a root.
+	reachable := b.graph.reachable(b.graph.Roots)
+	for _, e := range execs {
+		id := e.Ref().String()
+		if inWorkspace[id] && !reachable[id] {
+			b.graph.Roots = append(b.graph.Roots, id)
+			for r := range b.graph.reachable([]string{id}) {
+				reachable[r] = true
+			}
+		}
+	}
+	return b.graph
+}
+
+func newBuilder(ctx *context.Context) *builder {
+	return &builder{
+		ctx: ctx,
+		graph: &Graph{
+			Roots: make([]string, 0),
+			Nodes: make([]*Node, 0),
+			Edges: make([]*Edge, 0),
+			nodes: make(map[string]*Node),
+		},
+	}
+}
+
+func (b *builder) visit(e *executable.Executable) {
+	id := e.Ref().String()
+	if _, found := b.graph.nodes[id]; found {
+		return
+	}
+	b.addNode(&Node{ID: id, Workspace: e.Workspace(), Namespace: e.Namespace(), Description: e.Description})
+
+	for i, s := range e.Steps() {
+		if s.Ref == "" {
+			continue
+		}
+		edgeType := EdgeTypeSerial
+		if s.Parallel {
+			edgeType = EdgeTypeParallel
+		}
+		edge := &Edge{From: id, Type: edgeType, Step: i + 1, Condition: s.If, Retries: s.Retries}
+		expanded := context.ExpandRefInWorkspace(b.ctx, s.Ref, e.Workspace())
+		b.graph.Edges = append(b.graph.Edges, edge)
+		child, err := b.ctx.ExecutableCache.GetExecutableByRef(b.ctx.Logger, expanded)
+		if err != nil || child == nil {
+			edge.To = expanded.String()
+			if _, found := b.graph.nodes[edge.To]; !found {
+				ws, ns, _ := executable.ParseExecutableID(expanded.ID())
+				b.addNode(&Node{ID: edge.To, Workspace: ws, Namespace: ns, Missing: true})
+			}
+			continue
+		}
+		edge.To = child.Ref().String()
+		b.visit(child)
+	}
+}
+
+func (b *builder) addNode(n *Node) {
+	b.graph.nodes[n.ID] = n
+	b.graph.Nodes = append(b.graph.Nodes, n)
+}
+
+func (g *Graph) reachable(roots []string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := slices.Clone(roots)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, edge := range g.Children(id) {
+			queue = append(queue, edge.To)
+		}
+	}
+	return seen
+}
@@ -0,0 +1,137 @@
+package graph_test
+
+import (
+	stdCtx "context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/graph"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestGraph(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Graph Suite")
+}
+
+var _ = Describe("Graph", func() {
+	var (
+		ctx   *testUtils.ContextWithMocks
+		execs executable.ExecutableList
+	)
+
+	newExec := func(ws string, verb executable.Verb, name string) *executable.Executable {
+		e := &executable.Executable{Verb: verb, Name: name}
+		e.SetContext(ws, "/"+ws, "", "/"+ws+"/app.flow")
+		return e
+	}
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		ctx.Ctx.Config.CurrentNamespace = ""
+
+		deploy := newExec("ws", "deploy", "app")
+		deploy.Serial = &executable.SerialExecutableType{Execs: executable.SerialRefConfigList{
+			{Ref: "build api", If: `os == "linux"`, Retries: 2},
+			{Cmd: "echo done"},
+			{Ref: "start other/db"},
+		}}
+		build := newExec("ws", "build", "api")
+		build.Parallel = &executable.ParallelExecutableType{Execs: executable.ParallelRefConfigList{
+			{Ref: "test api"},
+			{Ref: "lint api"},
+		}}
+		test := newExec("ws", "test", "api")
+		test.Serial = &executable.SerialExecutableType{Execs: executable.SerialRefConfigList{
+			{Ref: "build api"},
+		}}
+		db := newExec("other", "start", "db")
+		standalone := newExec("ws", "run", "standalone")
+		standalone.Exec = &executable.ExecExecutableType{Cmd: "echo"}
+		execs = executable.ExecutableList{deploy, build, test, db, standalone}
+
+		ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, ref executable.Ref) (*executable.Executable, error) {
+				for _, e := range execs {
+					if e.Ref().Equals(ref) {
+						return e, nil
+					}
+				}
+				return nil, errors.New("not found")
+			}).AnyTimes()
+	})
+
+	It("should follow refs across workspaces and add missing executables", func() {
+		g := graph.ForExecutable(ctx.Ctx, execs[0])
+		Expect(g.Roots).To(Equal([]string{"deploy ws/app"}))
+
+		ids := make([]string, 0)
+		for _, n := range g.Nodes {
+			ids = append(ids, n.ID)
+		}
+		Expect(ids).To(Equal([]string{
+			"deploy ws/app", "build ws/api", "test ws/api", "lint ws/api", "start other/db",
+		}))
+		Expect(g.Node("lint ws/api").Missing).To(BeTrue())
+		Expect(g.Node("start other/db").Workspace).To(Equal("other"))
+
+		Expect(g.Edges).To(HaveLen(5))
+		Expect(*g.Edges[0]).To(Equal(graph.Edge{
+			From: "deploy ws/app", To: "build ws/api", Type: graph.EdgeTypeSerial, Step: 1,
+			Condition: `os == "linux"`, Retries: 2,
+		}))
+		Expect(g.Children("build ws/api")[0].Type).To(Equal(graph.EdgeTypeParallel))
+		Expect(g.Children("deploy ws/app")[1].Step).To(Equal(3))
+	})
+
+	It("should use unreferenced executables as the roots of a workspace", func() {
+		g := graph.ForWorkspace(ctx.Ctx, execs.FilterByWorkspace("ws"))
+		Expect(g.Roots).To(Equal([]string{"deploy ws/app"}))
+		Expect(g.Node("run ws/standalone")).To(BeNil())
+	})
+
+	It("should render the tree with edge details and cycles", func() {
+		g := graph.ForExecutable(ctx.Ctx, execs[0])
+		Expect(g.Tree()).To(Equal(`deploy ws/app
+├── build ws/api (if: os == "linux", retries: 2)
+│   ├── test ws/api (parallel)
+│   │   └── build ws/api (cycle)
+│   └── lint ws/api (parallel, not found)
+└── start other/db
+`))
+	})
+
+	It("should render DOT and Mermaid", func() {
+		g := graph.ForExecutable(ctx.Ctx, execs[0])
+		dot := g.DOT()
+		Expect(dot).To(HavePrefix("digraph flow {\n"))
+		Expect(dot).To(ContainSubstring(`label="ws";`))
+		Expect(dot).To(ContainSubstring(
+			`"deploy ws/app" -> "build ws/api" [label="1\nif: os == \"linux\"\nretries: 2"];`,
+		))
+		Expect(dot).To(ContainSubstring(`"build ws/api" -> "lint ws/api" [label="2", style=dashed];`))
+		Expect(dot).To(ContainSubstring(`"lint ws/api" [label="lint ws/api", color=red`))
+
+		mermaid := g.Mermaid()
+		Expect(mermaid).To(HavePrefix("flowchart LR\n"))
+		Expect(mermaid).To(ContainSubstring(`n0 -->|"1<br/>if: os == #quot;linux#quot;<br/>retries: 2"| n1`))
+		Expect(mermaid).To(ContainSubstring(`n1 -.->|"1"| n2`))
+		Expect(mermaid).To(ContainSubstring("class n3 missing"))
+	})
+
+	It("should marshal to JSON", func() {
+		str, err := graph.ForExecutable(ctx.Ctx, execs[0]).JSON()
+		Expect(err).NotTo(HaveOccurred())
+		var out map[string]any
+		Expect(json.Unmarshal([]byte(str), &out)).To(Succeed())
+		Expect(out).To(HaveKey("roots"))
+		Expect(out["nodes"]).To(HaveLen(5))
+		Expect(out["edges"]).To(HaveLen(5))
+	})
+})
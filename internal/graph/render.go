@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DOT renders the graph in the Graphviz DOT language. Executables are grouped into a cluster per workspace, parallel
+// edges are dashed, and missing executables are drawn in red.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph flow {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=rounded];\n")
+	for i, ws := range g.workspaces() {
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(ws))
+		for _, n := range g.Nodes {
+			if n.Workspace != ws {
+				continue
+			}
+			attrs := "label=" + dotQuote(n.ID)
+			if n.Missing {
+				attrs += ", color=red, style=\"rounded,dashed\""
+			}
+			fmt.Fprintf(&sb, "    %s [%s];\n", dotQuote(n.ID), attrs)
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range g.Edges {
+		attrs := "label=" + dotQuote(strings.Join(e.labels(), "\n"))
+		if e.Type == EdgeTypeParallel {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Executables are grouped into a subgraph per workspace, parallel
+// edges are dotted, and missing executables use the missing class.
+func (g *Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, ws := range g.workspaces() {
+		fmt.Fprintf(&sb, "  subgraph ws%d[%s]\n", i, mermaidQuote(ws))
+		for _, n := range g.Nodes {
+			if n.Workspace != ws {
+				continue
+			}
+			fmt.Fprintf(&sb, "    %s[%s]\n", ids[n.ID], mermaidQuote(n.ID))
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Type == EdgeTypeParallel {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "  %s %s|%s| %s\n", ids[e.From], arrow, mermaidQuote(strings.Join(e.labels(), "<br/>")), ids[e.To])
+	}
+	var missing []string
+	for _, n := range g.Nodes {
+		if n.Missing {
+			missing = append(missing, ids[n.ID])
+		}
+	}
+	if len(missing) > 0 {
+		sb.WriteString("  classDef missing stroke:#f00,stroke-dasharray:4\n")
+		fmt.Fprintf(&sb, "  class %s missing\n", strings.Join(missing, ","))
+	}
+	return sb.String()
+}
+
+// TreeNode is an executable in the tree of the graph. Notes describe the edge that the executable was reached from.
+type TreeNode struct {
+	ID       string
+	Notes    []string
+	Children []*TreeNode
+}
+
+// Label returns the ID of the executable followed by its notes.
+func (n *TreeNode) Label() string {
+	if len(n.Notes) == 0 {
+		return n.ID
+	}
+	return n.ID + " (" + strings.Join(n.Notes, ", ") + ")"
+}
+
+// TreeNodes returns the tree starting at each root. Executables that are reached again from one of their own
+// descendants are marked as a cycle instead of being expanded.
+func (g *Graph) TreeNodes() []*TreeNode {
+	roots := make([]*TreeNode, 0, len(g.Roots))
+	for _, root := range g.Roots {
+		node := &TreeNode{ID: root}
+		if n := g.Node(root); n != nil && n.Missing {
+			node.Notes = append(node.Notes, "not found")
+		}
+		node.Children = g.treeChildren(root, map[string]bool{root: true})
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+func (g *Graph) treeChildren(id string, path map[string]bool) []*TreeNode {
+	var children []*TreeNode
+	for _, e := range g.Children(id) {
+		node := &TreeNode{ID: e.To}
+		if e.Type == EdgeTypeParallel {
+			node.Notes = append(node.Notes, "parallel")
+		}
+		node.Notes = append(node.Notes, e.labels()[1:]...)
+		if n := g.Node(e.To); n != nil && n.Missing {
+			node.Notes = append(node.Notes, "not found")
+		}
+		if path[e.To] {
+			node.Notes = append(node.Notes, "cycle")
+		} else {
+			path[e.To] = true
+			node.Children = g.treeChildren(e.To, path)
+			delete(path, e.To)
+		}
+		children = append(children, node)
+	}
+	return children
+}
+
+// Tree renders the tree of the graph as indented text.
+func (g *Graph) Tree() string {
+	var sb strings.Builder
+	for _, root := range g.TreeNodes() {
+		sb.WriteString(root.Label() + "\n")
+		writeTree(&sb, root.Children, "")
+	}
+	return sb.String()
+}
+
+func writeTree(sb *strings.Builder, nodes []*TreeNode, indent string) {
+	for i, n := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+		sb.WriteString(indent + branch + n.Label() + "\n")
+		writeTree(sb, n.Children, indent+next)
+	}
+}
+
+// labels returns the step number followed by the condition and retries of the edge.
+func (e *Edge) labels() []string {
+	labels := []string{fmt.Sprintf("%d", e.Step)}
+	if e.Condition != "" {
+		labels = append(labels, "if: "+e.Condition)
+	}
+	if e.Retries > 0 {
+		labels = append(labels, fmt.Sprintf("retries: %d", e.Retries))
+	}
+	return labels
+}
+
+// workspaces returns the workspaces of the nodes in the order they were added.
+func (g *Graph) workspaces() []string {
+	var workspaces []string
+	seen := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if !seen[n.Workspace] {
+			seen[n.Workspace] = true
+			workspaces = append(workspaces, n.Workspace)
+		}
+	}
+	return workspaces
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
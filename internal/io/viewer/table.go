@@ -0,0 +1,221 @@
+package viewer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jahvon/tuikit/styles"
+	"github.com/jahvon/tuikit/types"
+	"github.com/jahvon/tuikit/views"
+)
+
+const (
+	TableViewType = "table"
+
+	maxColumnWidth = 40
+	tableHelp      = "[ ↑/↓: navigate ] [ s: sort by next column ] [ r: reverse sort ] [ /: filter ]"
+	filterHelp     = "[ enter: apply filter ] [ esc: clear filter ]"
+)
+
+// TableView displays rows of data in a table that can be sorted by column and filtered.
+type TableView struct {
+	columns []string
+	rows    [][]string
+	table   table.Model
+	filter  textinput.Model
+	theme   styles.Theme
+
+	sortColumn int
+	sortDesc   bool
+	filtering  bool
+	width      int
+	height     int
+}
+
+func NewTableView(state *types.RenderState, columns []string, rows [][]string) *TableView {
+	theme := *state.Theme
+	tableStyles := table.DefaultStyles()
+	tableStyles.Header = tableStyles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(theme.BorderColor).
+		BorderBottom(true).
+		Foreground(theme.SecondaryColor).
+		Bold(true)
+	tableStyles.Selected = tableStyles.Selected.Foreground(theme.PrimaryColor).Bold(true)
+	tableStyles.Cell = tableStyles.Cell.Foreground(theme.BodyColor)
+
+	filter := textinput.New()
+	filter.Prompt = "filter: "
+	v := &TableView{
+		columns:    columns,
+		rows:       rows,
+		filter:     filter,
+		theme:      theme,
+		sortColumn: -1,
+		table: table.New(
+			table.WithFocused(true),
+			table.WithStyles(tableStyles),
+		),
+	}
+	v.setSize(state.ContentWidth, state.ContentHeight)
+	v.refresh()
+	return v
+}
+
+func (v *TableView) setSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.table.SetWidth(width)
+	// The status line is displayed below the table.
+	v.table.SetHeight(max(height-1, 1))
+}
+
+// refresh updates the table with the rows that match the filter in the current sort order.
+func (v *TableView) refresh() {
+	query := strings.ToLower(strings.TrimSpace(v.filter.Value()))
+	rows := make([][]string, 0, len(v.rows))
+	for _, row := range v.rows {
+		if query == "" || strings.Contains(strings.ToLower(strings.Join(row, "\t")), query) {
+			rows = append(rows, row)
+		}
+	}
+	if v.sortColumn >= 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			if v.sortDesc {
+				return lessValue(rows[j][v.sortColumn], rows[i][v.sortColumn])
+			}
+			return lessValue(rows[i][v.sortColumn], rows[j][v.sortColumn])
+		})
+	}
+
+	columns := make([]table.Column, len(v.columns))
+	for i, col := range v.columns {
+		title := col
+		if i == v.sortColumn {
+			title += " ▲"
+			if v.sortDesc {
+				title = col + " ▼"
+			}
+		}
+		width := lipgloss.Width(title)
+		for _, row := range rows {
+			width = max(width, lipgloss.Width(row[i]))
+		}
+		columns[i] = table.Column{Title: title, Width: min(width, maxColumnWidth)}
+	}
+	tableRows := make([]table.Row, len(rows))
+	for i, row := range rows {
+		tableRows[i] = row
+	}
+	// The rows are cleared first so that the table does not render rows with the previous columns.
+	v.table.SetRows(nil)
+	v.table.SetColumns(columns)
+	v.table.SetRows(tableRows)
+	if v.table.Cursor() >= len(tableRows) {
+		v.table.SetCursor(max(len(tableRows)-1, 0))
+	}
+}
+
+// lessValue compares the values as numbers when both are numeric.
+func lessValue(a, b string) bool {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		return af < bf
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+func (v *TableView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *TableView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.RenderState:
+		v.setSize(msg.ContentWidth, msg.ContentHeight)
+		return v, nil
+	case tea.WindowSizeMsg:
+		// The container sends the window size instead of the render state while the filter input is active.
+		v.setSize(msg.Width, msg.Height)
+		return v, nil
+	case tea.KeyMsg:
+		if v.filtering {
+			return v.updateFilter(msg)
+		}
+		switch msg.String() {
+		case "/":
+			v.filtering = true
+			return v, v.filter.Focus()
+		case "s":
+			if len(v.columns) > 0 {
+				v.sortColumn = (v.sortColumn + 1) % len(v.columns)
+				v.refresh()
+			}
+			return v, nil
+		case "r":
+			v.sortDesc = !v.sortDesc
+			v.refresh()
+			return v, nil
+		}
+	}
+	var cmd tea.Cmd
+	v.table, cmd = v.table.Update(msg)
+	return v, cmd
+}
+
+func (v *TableView) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		v.filtering = false
+		v.filter.Blur()
+		return v, nil
+	case "esc":
+		v.filtering = false
+		v.filter.Blur()
+		v.filter.SetValue("")
+		v.refresh()
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.filter, cmd = v.filter.Update(msg)
+	v.refresh()
+	return v, cmd
+}
+
+func (v *TableView) View() string {
+	if len(v.columns) == 0 {
+		return v.theme.RenderInfo("No data to display")
+	}
+	status := v.theme.RenderInfo(fmt.Sprintf("%d of %d rows", len(v.table.Rows()), len(v.rows)))
+	if v.filtering || v.filter.Value() != "" {
+		status = v.filter.View() + "  " + status
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, v.table.View(), status)
+}
+
+func (v *TableView) HelpMsg() string {
+	if v.filtering {
+		return filterHelp
+	}
+	return tableHelp
+}
+
+func (v *TableView) ShowFooter() bool {
+	return true
+}
+
+// Type returns the form view type while the filter is being typed so that the container forwards all key presses
+// (including the quit and help keys) to the filter input.
+func (v *TableView) Type() string {
+	if v.filtering {
+		return views.FormViewType
+	}
+	return TableViewType
+}
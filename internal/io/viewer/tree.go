@@ -0,0 +1,283 @@
+package viewer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jahvon/tuikit/styles"
+	"github.com/jahvon/tuikit/types"
+)
+
+const (
+	TreeViewType = "tree"
+
+	treeHelp = "[ ↑/↓: navigate ] [ enter: toggle ] [ →/←: expand/collapse ] [ e/c: expand/collapse all ]"
+)
+
+// TreeItem is an entry of a tree that is built by the caller instead of parsed from data. Items are shown in the
+// order they are given.
+type TreeItem struct {
+	Label    string
+	Children []*TreeItem
+}
+
+type treeNode struct {
+	key      string
+	value    interface{}
+	children []*treeNode
+	parent   *treeNode
+	expanded bool
+	depth    int
+	// item is set for nodes of a TreeItem, which are shown by their label only.
+	item bool
+}
+
+// TreeView displays JSON or YAML data, or items built by the caller, as a collapsible tree.
+type TreeView struct {
+	roots   []*treeNode
+	visible []*treeNode
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	theme   styles.Theme
+}
+
+func NewTreeView(state *types.RenderState, value interface{}) *TreeView {
+	return newTreeView(state, newTreeNodes(value, nil, 0))
+}
+
+// NewItemTreeView returns a tree view of the items.
+func NewItemTreeView(state *types.RenderState, items []*TreeItem) *TreeView {
+	return newTreeView(state, newItemNodes(items, nil, 0))
+}
+
+func newTreeView(state *types.RenderState, roots []*treeNode) *TreeView {
+	v := &TreeView{
+		roots:  roots,
+		width:  state.ContentWidth,
+		height: state.ContentHeight,
+		theme:  *state.Theme,
+	}
+	// The top level is expanded so that the structure of the data is visible when the view opens.
+	for _, n := range v.roots {
+		n.expanded = true
+	}
+	v.refresh()
+	return v
+}
+
+func newTreeNodes(value interface{}, parent *treeNode, depth int) []*treeNode {
+	var nodes []*treeNode
+	switch val := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			nodes = append(nodes, newTreeNode(key, val[key], parent, depth))
+		}
+	case []interface{}:
+		for i, item := range val {
+			nodes = append(nodes, newTreeNode(fmt.Sprintf("[%d]", i), item, parent, depth))
+		}
+	default:
+		nodes = append(nodes, &treeNode{value: val, parent: parent, depth: depth})
+	}
+	return nodes
+}
+
+func newTreeNode(key string, value interface{}, parent *treeNode, depth int) *treeNode {
+	n := &treeNode{key: key, value: value, parent: parent, depth: depth}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		n.children = newTreeNodes(value, n, depth+1)
+	}
+	return n
+}
+
+func newItemNodes(items []*TreeItem, parent *treeNode, depth int) []*treeNode {
+	nodes := make([]*treeNode, 0, len(items))
+	for _, item := range items {
+		n := &treeNode{key: item.Label, parent: parent, depth: depth, item: true}
+		n.children = newItemNodes(item.Children, n, depth+1)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+func (n *treeNode) isContainer() bool {
+	if n.item {
+		return len(n.children) > 0
+	}
+	switch n.value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+func (n *treeNode) setExpanded(expanded bool) {
+	if n.isContainer() {
+		n.expanded = expanded
+	}
+	for _, child := range n.children {
+		child.setExpanded(expanded)
+	}
+}
+
+// refresh updates the list of nodes that are visible based on which nodes are expanded.
+func (v *TreeView) refresh() {
+	v.visible = v.visible[:0]
+	var walk func(nodes []*treeNode)
+	walk = func(nodes []*treeNode) {
+		for _, n := range nodes {
+			v.visible = append(v.visible, n)
+			if n.expanded {
+				walk(n.children)
+			}
+		}
+	}
+	walk(v.roots)
+	v.cursor = min(v.cursor, max(len(v.visible)-1, 0))
+	v.scroll()
+}
+
+func (v *TreeView) scroll() {
+	height := max(v.height, 1)
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if v.cursor >= v.offset+height {
+		v.offset = v.cursor - height + 1
+	}
+}
+
+func (v *TreeView) Init() tea.Cmd {
+	return nil
+}
+
+//nolint:gocognit
+func (v *TreeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.RenderState:
+		v.width = msg.ContentWidth
+		v.height = msg.ContentHeight
+		v.scroll()
+	case tea.KeyMsg:
+		if len(v.visible) == 0 {
+			return v, nil
+		}
+		current := v.visible[v.cursor]
+		switch msg.String() {
+		case "up", "k":
+			if v.cursor > 0 {
+				v.cursor--
+			}
+		case "down", "j":
+			if v.cursor < len(v.visible)-1 {
+				v.cursor++
+			}
+		case "enter", " ":
+			if current.isContainer() {
+				current.expanded = !current.expanded
+			}
+		case "right", "l":
+			if current.isContainer() {
+				current.expanded = true
+			}
+		case "left":
+			if current.isContainer() && current.expanded {
+				current.expanded = false
+			} else if current.parent != nil {
+				// Move to the parent so that it can be collapsed.
+				for i, n := range v.visible {
+					if n == current.parent {
+						v.cursor = i
+						break
+					}
+				}
+			}
+		case "e":
+			for _, n := range v.roots {
+				n.setExpanded(true)
+			}
+		case "c":
+			for _, n := range v.roots {
+				n.setExpanded(false)
+			}
+		}
+		v.refresh()
+	}
+	return v, nil
+}
+
+func (v *TreeView) View() string {
+	if len(v.visible) == 0 {
+		return v.theme.RenderInfo("No data to display")
+	}
+	keyStyle := lipgloss.NewStyle().Foreground(v.theme.SecondaryColor).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(v.theme.BodyColor)
+	summaryStyle := lipgloss.NewStyle().Foreground(v.theme.Gray)
+	selectedStyle := lipgloss.NewStyle().Foreground(v.theme.PrimaryColor).Bold(true)
+
+	end := min(v.offset+max(v.height, 1), len(v.visible))
+	lines := make([]string, 0, end-v.offset)
+	for i := v.offset; i < end; i++ {
+		n := v.visible[i]
+		marker := "  "
+		if n.isContainer() {
+			marker = "▸ "
+			if n.expanded {
+				marker = "▾ "
+			}
+		}
+		var line string
+		switch {
+		case n.item:
+			line = keyStyle.Render(n.key)
+		case n.isContainer():
+			line = keyStyle.Render(n.key) + " " + summaryStyle.Render(summary(n.value))
+		case n.key == "":
+			line = valueStyle.Render(formatScalar(n.value))
+		default:
+			line = keyStyle.Render(n.key) + ": " + valueStyle.Render(formatScalar(n.value))
+		}
+		if i == v.cursor {
+			marker = selectedStyle.Render(marker)
+		}
+		line = strings.Repeat("  ", n.depth) + marker + line
+		if v.width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(v.width).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func summary(value interface{}) string {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		return "{" + strconv.Itoa(len(val)) + "}"
+	case []interface{}:
+		return "[" + strconv.Itoa(len(val)) + "]"
+	}
+	return ""
+}
+
+func (v *TreeView) HelpMsg() string {
+	return treeHelp
+}
+
+func (v *TreeView) ShowFooter() bool {
+	return true
+}
+
+func (v *TreeView) Type() string {
+	return TreeViewType
+}
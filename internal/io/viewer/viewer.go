@@ -0,0 +1,189 @@
+package viewer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jahvon/tuikit"
+	"github.com/jahvon/tuikit/types"
+	"github.com/jahvon/tuikit/views"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/types/executable"
+)
+
+// NewView returns the TUI view used to browse the content in the given format. The name is used to detect
+// CSV and TSV content from its file extension.
+func NewView(
+	state *types.RenderState,
+	format executable.ViewFormat,
+	name, content string,
+) (tuikit.View, error) {
+	switch format {
+	case executable.ViewFormatJson, executable.ViewFormatYaml:
+		value, err := ParseValue(content)
+		if err != nil {
+			return nil, err
+		}
+		return NewTreeView(state, value), nil
+	case executable.ViewFormatTable:
+		columns, rows, err := ParseTable(name, content)
+		if err != nil {
+			return nil, err
+		}
+		return NewTableView(state, columns, rows), nil
+	default:
+		return views.NewMarkdownView(state, content), nil
+	}
+}
+
+// Format returns the content formatted in the given format for printing to a terminal.
+func Format(format executable.ViewFormat, name, content string) (string, error) {
+	switch format {
+	case executable.ViewFormatJson:
+		value, err := ParseValue(content)
+		if err != nil {
+			return "", err
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case executable.ViewFormatYaml:
+		value, err := ParseValue(content)
+		if err != nil {
+			return "", err
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case executable.ViewFormatTable:
+		columns, rows, err := ParseTable(name, content)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, strings.Join(columns, "\t"))
+		for _, row := range rows {
+			_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	default:
+		return content, nil
+	}
+}
+
+// ParseValue parses the content as JSON or YAML.
+func ParseValue(content string) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(content), &value); err == nil {
+		return value, nil
+	}
+	if err := yaml.Unmarshal([]byte(content), &value); err != nil {
+		return nil, errors.Wrap(err, "content is not valid JSON or YAML")
+	}
+	return value, nil
+}
+
+// ParseTable parses the content into table columns and rows. Content with a `.csv` or `.tsv` extension or that is
+// not structured data is read as delimited values, otherwise it must be a JSON or YAML list of objects. The columns
+// of a list of objects are the sorted keys of all objects.
+func ParseTable(name, content string) ([]string, [][]string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return parseDelimited(content, ',')
+	case ".tsv":
+		return parseDelimited(content, '\t')
+	}
+
+	value, err := ParseValue(content)
+	if _, isStr := value.(string); err != nil || isStr {
+		firstLine, _, _ := strings.Cut(content, "\n")
+		if strings.Contains(firstLine, "\t") {
+			return parseDelimited(content, '\t')
+		}
+		return parseDelimited(content, ',')
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a list of objects, got %T", value)
+	}
+	keys := make(map[string]bool)
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("expected a list of objects, got an item of type %T", item)
+		}
+		for key := range obj {
+			keys[key] = true
+		}
+	}
+	columns := make([]string, 0, len(keys))
+	for key := range keys {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		obj, _ := item.(map[string]interface{})
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			if val, found := obj[col]; found {
+				row[i] = formatScalar(val)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+func parseDelimited(content string, delimiter rune) ([]string, [][]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read delimited values")
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("no rows found")
+	}
+	columns := records[0]
+	rows := make([][]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make([]string, len(columns))
+		copy(row, record)
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+// formatScalar returns strings as-is and all other values as JSON.
+func formatScalar(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(data)
+}
@@ -0,0 +1,106 @@
+package viewer_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jahvon/tuikit/styles"
+	"github.com/jahvon/tuikit/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/io/viewer"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestViewer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Viewer Suite")
+}
+
+var _ = Describe("Viewer", func() {
+	DescribeTable("ParseTable",
+		func(name, content string, expectedColumns []string, expectedRows [][]string) {
+			columns, rows, err := viewer.ParseTable(name, content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal(expectedColumns))
+			Expect(rows).To(Equal(expectedRows))
+		},
+		Entry("csv extension", "data.csv", "name,age\nalice,30\nbob,25\n",
+			[]string{"name", "age"}, [][]string{{"alice", "30"}, {"bob", "25"}}),
+		Entry("tsv extension", "data.TSV", "name\tage\nalice\t30\n",
+			[]string{"name", "age"}, [][]string{{"alice", "30"}}),
+		Entry("comma separated without extension", "", "name,age\nalice,30\n",
+			[]string{"name", "age"}, [][]string{{"alice", "30"}}),
+		Entry("tab separated without extension", "", "name\tage\nalice\t30\n",
+			[]string{"name", "age"}, [][]string{{"alice", "30"}}),
+		Entry("short rows are padded", "data.csv", "name,age\nalice\n",
+			[]string{"name", "age"}, [][]string{{"alice", ""}}),
+		Entry("json list of objects", "data.json", `[{"name": "alice", "age": 30}, {"name": "bob", "admin": true}]`,
+			[]string{"admin", "age", "name"}, [][]string{{"", "30", "alice"}, {"true", "", "bob"}}),
+		Entry("yaml list of objects", "", "- name: alice\n  tags: [a, b]\n- name: bob\n  tags: null\n",
+			[]string{"name", "tags"}, [][]string{{"alice", `["a","b"]`}, {"bob", "null"}}),
+	)
+
+	DescribeTable("ParseTable errors",
+		func(name, content, expectedErr string) {
+			_, _, err := viewer.ParseTable(name, content)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("object", "", `{"name": "alice"}`, "expected a list of objects, got map[string]interface {}"),
+		Entry("list of scalars", "", `[1, 2]`, "expected a list of objects, got an item of type float64"),
+		Entry("empty csv", "data.csv", "", "no rows found"),
+		Entry("invalid csv", "data.csv", "name\n\"alice\n", "unable to read delimited values"),
+	)
+
+	DescribeTable("ParseValue",
+		func(content string, expected interface{}) {
+			value, err := viewer.ParseValue(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(expected))
+		},
+		Entry("json object", `{"a": 1, "b": [true]}`, map[string]interface{}{"a": float64(1), "b": []interface{}{true}}),
+		Entry("yaml object", "a: 1\nb:\n  - true\n", map[string]interface{}{"a": 1, "b": []interface{}{true}}),
+		Entry("yaml scalar", "hello", "hello"),
+	)
+
+	It("should return an error when the content is not JSON or YAML", func() {
+		_, err := viewer.ParseValue("a: [1")
+		Expect(err).To(MatchError(ContainSubstring("content is not valid JSON or YAML")))
+	})
+
+	DescribeTable("Format",
+		func(format executable.ViewFormat, name, content, expected string) {
+			out, err := viewer.Format(format, name, content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(expected))
+		},
+		Entry("yaml as json", executable.ViewFormatJson, "", "a: 1\nb: [x]\n",
+			"{\n  \"a\": 1,\n  \"b\": [\n    \"x\"\n  ]\n}\n"),
+		Entry("json as yaml", executable.ViewFormatYaml, "", `{"a": 1, "b": ["x"]}`,
+			"a: 1\nb:\n    - x\n"),
+		Entry("csv as table", executable.ViewFormatTable, "data.csv", "name,age\nalice,30\n",
+			"name   age\nalice  30\n"),
+		Entry("json as table", executable.ViewFormatTable, "", `[{"name": "bob", "age": 25}]`,
+			"age  name\n25   bob\n"),
+		Entry("markdown as-is", executable.ViewFormatMarkdown, "", "# Title\n", "# Title\n"),
+	)
+
+	It("should return an error when formatting invalid content", func() {
+		_, err := viewer.Format(executable.ViewFormatJson, "", "a: [1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should show items in order and collapse their children", func() {
+		theme := styles.BaseTheme()
+		state := &types.RenderState{ContentWidth: 80, ContentHeight: 10, Theme: &theme}
+		view := viewer.NewItemTreeView(state, []*viewer.TreeItem{
+			{Label: "deploy app", Children: []*viewer.TreeItem{{Label: "build web"}, {Label: "build api"}}},
+		})
+		Expect(view.View()).To(MatchRegexp(`(?s)deploy app.*build web.*build api`))
+
+		_, _ = view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		Expect(view.View()).To(ContainSubstring("deploy app"))
+		Expect(view.View()).NotTo(ContainSubstring("build web"))
+	})
+})
@@ -0,0 +1,214 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/jahvon/flow/internal/vault"
+	"github.com/jahvon/flow/types/executable"
+)
+
+var (
+	verbValueRegex   = regexp.MustCompile(`^\s*(?:-\s+)?verb:\s*["']?([\w-]*)$`)
+	refValueRegex    = regexp.MustCompile(`^\s*(?:-\s+)?ref:\s*["']?([^"'#]*)$`)
+	secretValueRegex = regexp.MustCompile(`(?i)secretRef:\s*["']?([\w.-]*)$`)
+	listItemRegex    = regexp.MustCompile(`^(\s*)-\s*["']?([^\s"']*)$`)
+	argsKeyRegex     = regexp.MustCompile(`^(\s*)(-\s+)?args:\s*$`)
+	keyRegex         = regexp.MustCompile(`^(\s*)(-\s+)?([\w-]+):\s*(.*)$`)
+)
+
+func (s *Server) completion(doc *document, pos Position) []CompletionItem {
+	prefix := doc.linePrefix(pos)
+	switch {
+	case verbValueRegex.MatchString(prefix):
+		typed := verbValueRegex.FindStringSubmatch(prefix)[1]
+		return verbCompletions(editRange(pos, typed))
+	case refValueRegex.MatchString(prefix):
+		typed := refValueRegex.FindStringSubmatch(prefix)[1]
+		return s.refCompletions(doc, editRange(pos, typed))
+	case secretValueRegex.MatchString(prefix):
+		typed := secretValueRegex.FindStringSubmatch(prefix)[1]
+		return s.secretCompletions(editRange(pos, typed))
+	case listItemRegex.MatchString(prefix):
+		ref, found := doc.argsItemRef(pos.Line, len(listItemRegex.FindStringSubmatch(prefix)[1]))
+		if !found {
+			return nil
+		}
+		e, err := s.resolveRef(doc, ref)
+		if err != nil {
+			return nil
+		}
+		typed := listItemRegex.FindStringSubmatch(prefix)[2]
+		return argCompletions(e, editRange(pos, typed))
+	}
+	return nil
+}
+
+func verbCompletions(rng Range) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	for _, verb := range executable.SortedValidVerbs() {
+		related := make([]string, 0)
+		for _, v := range executable.RelatedVerbs(executable.Verb(verb)) {
+			if v.String() != verb {
+				related = append(related, v.String())
+			}
+		}
+		slices.Sort(related)
+		items = append(items, CompletionItem{
+			Label:    verb,
+			Kind:     completionKindConstant,
+			Detail:   "related: " + strings.Join(related, ", "),
+			TextEdit: &TextEdit{Range: rng, NewText: verb},
+		})
+	}
+	return items
+}
+
+func (s *Server) refCompletions(doc *document, rng Range) []CompletionItem {
+	execs, err := s.ctx.ExecutableCache.GetExecutableList(s.ctx.Logger)
+	if err != nil {
+		s.ctx.Logger.Errorf("unable to list executables: %v", err)
+	}
+	if doc.flowFile != nil {
+		execs = append(execs, doc.flowFile.Executables...)
+	}
+	refs := make(map[string]CompletionItem)
+	for _, e := range execs {
+		ref := e.Ref().String()
+		item := CompletionItem{
+			Label:    ref,
+			Kind:     completionKindReference,
+			Detail:   e.FlowFilePath(),
+			TextEdit: &TextEdit{Range: rng, NewText: ref},
+		}
+		if e.Description != "" {
+			item.Documentation = &MarkupContent{Kind: markupKindMarkdown, Value: e.Description}
+		}
+		refs[ref] = item
+	}
+	labels := maps.Keys(refs)
+	slices.Sort(labels)
+	items := make([]CompletionItem, 0, len(labels))
+	for _, label := range labels {
+		items = append(items, refs[label])
+	}
+	return items
+}
+
+func (s *Server) secretCompletions(rng Range) []CompletionItem {
+	secrets, err := vault.NewVault(s.ctx.Logger).GetAllSecrets()
+	if err != nil {
+		// The vault cannot be read without the encryption key, so there is nothing to complete.
+		return nil
+	}
+	names := maps.Keys(secrets)
+	slices.Sort(names)
+	items := make([]CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, CompletionItem{
+			Label:    name,
+			Kind:     completionKindValue,
+			TextEdit: &TextEdit{Range: rng, NewText: name},
+		})
+	}
+	return items
+}
+
+func argCompletions(e *executable.Executable, rng Range) []CompletionItem {
+	env := e.Env()
+	if env == nil {
+		return nil
+	}
+	items := make([]CompletionItem, 0)
+	for _, arg := range env.Args {
+		if arg.Flag == "" {
+			continue
+		}
+		detail := arg.EnvKey
+		if arg.Type != "" {
+			detail += fmt.Sprintf(" (%s)", arg.Type)
+		}
+		switch {
+		case arg.Required:
+			detail += ", required"
+		case arg.Default != "":
+			detail += fmt.Sprintf(", default: %s", arg.Default)
+		}
+		items = append(items, CompletionItem{
+			Label:    arg.Flag + "=",
+			Kind:     completionKindField,
+			Detail:   detail,
+			TextEdit: &TextEdit{Range: rng, NewText: arg.Flag + "="},
+		})
+	}
+	return items
+}
+
+// argsItemRef returns the ref of the serial or parallel exec whose `args` list contains the item on the line.
+// The flowfile is usually not valid YAML while the item is being typed, so the lines around it are scanned instead.
+func (d *document) argsItemRef(line, dashCol int) (executable.Ref, bool) {
+	argsLine, keyCol := -1, -1
+	for i := line - 1; i >= 0; i-- {
+		text := d.lines[i]
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		if m := argsKeyRegex.FindStringSubmatch(text); m != nil && len(m[1])+len(m[2]) <= dashCol {
+			argsLine, keyCol = i, len(m[1])+len(m[2])
+			break
+		}
+		if m := listItemRegex.FindStringSubmatch(text); m != nil && len(m[1]) == dashCol {
+			continue
+		}
+		return "", false
+	}
+	if argsLine < 0 {
+		return "", false
+	}
+
+	// Look for the `ref` key of the same list item, which is at the same column as the `args` key.
+	findRef := func(i int) (ref executable.Ref, stop bool) {
+		m := keyRegex.FindStringSubmatch(d.lines[i])
+		if m == nil {
+			return "", false
+		}
+		col := len(m[1]) + len(m[2])
+		if col < keyCol {
+			return "", true
+		}
+		if col == keyCol && m[3] == "ref" {
+			return executable.Ref(strings.Trim(strings.TrimSpace(m[4]), `"'`)), true
+		}
+		return "", col == keyCol && m[2] != ""
+	}
+	// The item starts at the `args` line when it is the first key of the item, otherwise the keys above it are
+	// part of the same item.
+	if argsKeyRegex.FindStringSubmatch(d.lines[argsLine])[2] == "" {
+		for i := argsLine - 1; i >= 0; i-- {
+			if ref, stop := findRef(i); ref != "" {
+				return ref, true
+			} else if stop {
+				break
+			}
+		}
+	}
+	for i := argsLine + 1; i < len(d.lines); i++ {
+		if ref, stop := findRef(i); ref != "" {
+			return ref, true
+		} else if stop {
+			break
+		}
+	}
+	return "", false
+}
+
+// editRange returns the range of the text that was typed before the position, which completions replace.
+func editRange(pos Position, typed string) Range {
+	start := pos
+	start.Character -= len([]rune(typed))
+	return Range{Start: start, End: pos}
+}
@@ -0,0 +1,61 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jahvon/flow/types/executable"
+)
+
+const diagnosticSource = "flow"
+
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+): (.+)`)
+
+// diagnostics returns the YAML errors of the document, the import errors, the validation errors of its executables, and
+// the refs that cannot be resolved.
+func (s *Server) diagnostics(doc *document) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	if doc.parseErr != nil {
+		matches := yamlErrorLineRegex.FindAllStringSubmatch(doc.parseErr.Error(), -1)
+		if len(matches) == 0 {
+			return append(diagnostics, newDiagnostic(doc.lineRange(Position{}), severityError, doc.parseErr.Error()))
+		}
+		for _, m := range matches {
+			line, _ := strconv.Atoi(m[1])
+			diagnostics = append(diagnostics, newDiagnostic(
+				doc.lineRange(Position{Line: line - 1}), severityError, m[2],
+			))
+		}
+		return diagnostics
+	}
+
+	if doc.importErr != nil && len(doc.root.Content) > 0 {
+		rng := doc.lineRange(Position{})
+		if key, _ := mappingValue(doc.root.Content[0], "imports"); key != nil {
+			rng = nodeRange(key)
+		}
+		diagnostics = append(diagnostics, newDiagnostic(rng, severityError, doc.importErr.Error()))
+	}
+	for i, node := range doc.executableNodes() {
+		if i >= len(doc.flowFile.Executables) {
+			break
+		}
+		if err := doc.flowFile.Executables[i].Validate(); err != nil {
+			rng := doc.lineRange(Position{Line: node.Line - 1, Character: node.Column - 1})
+			diagnostics = append(diagnostics, newDiagnostic(rng, severityError, err.Error()))
+		}
+	}
+	for _, ref := range doc.refNodes() {
+		if _, err := s.resolveRef(doc, executable.Ref(ref.Value)); err != nil {
+			diagnostics = append(diagnostics, newDiagnostic(
+				nodeRange(ref), severityWarning, fmt.Sprintf("unable to resolve %s: %v", ref.Value, err),
+			))
+		}
+	}
+	return diagnostics
+}
+
+func newDiagnostic(rng Range, severity int, msg string) Diagnostic {
+	return Diagnostic{Range: rng, Severity: severity, Source: diagnosticSource, Message: msg}
+}
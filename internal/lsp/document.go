@@ -0,0 +1,202 @@
+package lsp
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// document is an open flowfile. The flowfile and its YAML node tree are nil when the text cannot be parsed, which is
+// common while the file is being edited, so features that only need the current line work from the raw lines.
+// Imports are resolved when the document is parsed, and the executables of imported files are added after the ones
+// defined in the document.
+type document struct {
+	uri   string
+	path  string
+	lines []string
+
+	workspace     string
+	workspacePath string
+
+	flowFile  *executable.FlowFile
+	root      *yaml.Node
+	parseErr  error
+	importErr error
+}
+
+func newDocument(ctx *context.Context, uri, text string) *document {
+	doc := &document{
+		uri:   uri,
+		path:  uriToPath(uri),
+		lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+	}
+	doc.workspace, doc.workspacePath = workspaceForPath(ctx, doc.path)
+
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(text), root); err != nil {
+		doc.parseErr = err
+		return doc
+	}
+	flowFile := &executable.FlowFile{}
+	if err := yaml.Unmarshal([]byte(text), flowFile); err != nil {
+		doc.parseErr = err
+		return doc
+	}
+	doc.importErr = filesystem.ResolveFlowFileImports(flowFile, doc.path, doc.workspacePath)
+	flowFile.SetDefaults()
+	flowFile.SetContext(doc.workspace, doc.workspacePath, doc.path)
+	doc.root = root
+	doc.flowFile = flowFile
+	return doc
+}
+
+// linePrefix returns the text of the line before the position.
+func (d *document) linePrefix(pos Position) string {
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return ""
+	}
+	line := []rune(d.lines[pos.Line])
+	if pos.Character > len(line) {
+		return string(line)
+	}
+	return string(line[:pos.Character])
+}
+
+// executableNodes returns the mapping node of each executable in the flowfile, in the order they are defined.
+func (d *document) executableNodes() []*yaml.Node {
+	if d.root == nil || len(d.root.Content) == 0 {
+		return nil
+	}
+	_, execs := mappingValue(d.root.Content[0], "executables")
+	if execs == nil || execs.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return execs.Content
+}
+
+// refNodes returns the values of the `ref` fields in the `execs` of serial and parallel executables.
+func (d *document) refNodes() []*yaml.Node {
+	var refs []*yaml.Node
+	for _, execNode := range d.executableNodes() {
+		for _, typ := range []string{"serial", "parallel"} {
+			_, typeNode := mappingValue(execNode, typ)
+			_, execsNode := mappingValue(typeNode, "execs")
+			if execsNode == nil || execsNode.Kind != yaml.SequenceNode {
+				continue
+			}
+			for _, item := range execsNode.Content {
+				if _, ref := mappingValue(item, "ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// findExecutable returns the executable defined in the document that matches the ref.
+func (d *document) findExecutable(ref executable.Ref) (*executable.Executable, *yaml.Node) {
+	if d.flowFile == nil {
+		return nil, nil
+	}
+	nodes := d.executableNodes()
+	for i, e := range d.flowFile.Executables {
+		if e.Ref().Equals(ref) && i < len(nodes) {
+			return e, nodes[i]
+		}
+	}
+	return nil, nil
+}
+
+// executableRange returns the range of the name of the executable in the flowfile at path. The start of the file is
+// returned when the executable is not defined in the file, like executables generated from scripts.
+func executableRange(path string, e *executable.Executable) Range {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return Range{}
+	}
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(data, root); err != nil || len(root.Content) == 0 {
+		return Range{}
+	}
+	_, execs := mappingValue(root.Content[0], "executables")
+	if execs == nil {
+		return Range{}
+	}
+	for _, execNode := range execs.Content {
+		_, verb := mappingValue(execNode, "verb")
+		_, name := mappingValue(execNode, "name")
+		if verb != nil && name != nil && executable.Verb(verb.Value).Equals(e.Verb) && name.Value == e.Name {
+			return nodeRange(name)
+		}
+	}
+	return Range{}
+}
+
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// nodeRange returns the range of a scalar node. YAML node positions start at 1 while LSP positions start at 0.
+func nodeRange(node *yaml.Node) Range {
+	start := Position{Line: node.Line - 1, Character: node.Column - 1}
+	end := start
+	if node.Kind == yaml.ScalarNode && !strings.Contains(node.Value, "\n") {
+		end.Character += len([]rune(node.Value))
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			end.Character += 2
+		}
+	}
+	return Range{Start: start, End: end}
+}
+
+// lineRange returns the range from the position to the end of its line.
+func (d *document) lineRange(pos Position) Range {
+	end := pos
+	if pos.Line >= 0 && pos.Line < len(d.lines) {
+		end.Character = len([]rune(d.lines[pos.Line]))
+	}
+	return Range{Start: pos, End: end}
+}
+
+// workspaceForPath returns the registered workspace that contains the path. The current workspace is used for files
+// outside of all workspaces.
+func workspaceForPath(ctx *context.Context, path string) (string, string) {
+	var name, wsPath string
+	for wsName, p := range ctx.Config.Workspaces {
+		if (path == p || strings.HasPrefix(path, p+string(filepath.Separator))) && len(p) > len(wsPath) {
+			name, wsPath = wsName, p
+		}
+	}
+	if name == "" && ctx.CurrentWorkspace != nil {
+		return ctx.CurrentWorkspace.AssignedName(), ctx.CurrentWorkspace.Location()
+	}
+	return name, wsPath
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,259 @@
+package lsp_test
+
+import (
+	"bufio"
+	stdCtx "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/lsp"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestLSP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LSP Suite")
+}
+
+const docText = `executables:
+  - verb: deploy
+    name: app
+    description: Deploys the app
+    serial:
+      execs:
+        - ref: build api
+          args:
+            - tag=v1
+        - ref: build missing
+  - verb: build
+    name: broken
+`
+
+type rpcMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// client sends requests to the server and reads its responses and notifications in the background.
+type client struct {
+	in            io.WriteCloser
+	msgs          chan *rpcMessage
+	notifications []*rpcMessage
+	nextID        int
+}
+
+func newClient(in io.WriteCloser, out io.Reader) *client {
+	c := &client{in: in, msgs: make(chan *rpcMessage, 100)}
+	go func() {
+		defer GinkgoRecover()
+		reader := bufio.NewReader(out)
+		for {
+			header, err := textproto.NewReader(reader).ReadMIMEHeader()
+			if err != nil {
+				close(c.msgs)
+				return
+			}
+			length, err := strconv.Atoi(header.Get("Content-Length"))
+			Expect(err).NotTo(HaveOccurred())
+			body := make([]byte, length)
+			_, err = io.ReadFull(reader, body)
+			Expect(err).NotTo(HaveOccurred())
+			msg := &rpcMessage{}
+			Expect(json.Unmarshal(body, msg)).To(Succeed())
+			c.msgs <- msg
+		}
+	}()
+	return c
+}
+
+func (c *client) send(id *int, method string, params any) {
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func (c *client) notify(method string, params any) {
+	c.send(nil, method, params)
+}
+
+func (c *client) call(method string, params any, result any) {
+	c.nextID++
+	id := c.nextID
+	c.send(&id, method, params)
+	for msg := range c.msgs {
+		if msg.ID == nil {
+			c.notifications = append(c.notifications, msg)
+			continue
+		}
+		Expect(*msg.ID).To(Equal(id))
+		Expect(msg.Error).To(BeNil())
+		Expect(json.Unmarshal(msg.Result, result)).To(Succeed())
+		return
+	}
+	Fail("server closed the connection")
+}
+
+func position(uri string, line, char int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": line, "character": char},
+	}
+}
+
+var _ = Describe("Server", func() {
+	var (
+		c         *client
+		docURI    string
+		otherPath string
+	)
+
+	BeforeEach(func() {
+		ctx := testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		ctx.Logger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+		ctx.Logger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+		wsDir := ctx.Ctx.CurrentWorkspace.Location()
+		wsName := ctx.Ctx.CurrentWorkspace.AssignedName()
+		docURI = "file://" + filepath.Join(wsDir, "app.flow")
+
+		otherPath = filepath.Join(wsDir, "api.flow")
+		Expect(os.WriteFile(otherPath, []byte("executables:\n  - verb: build\n    name: api\n"), 0600)).To(Succeed())
+		api := &executable.Executable{
+			Verb:        "build",
+			Name:        "api",
+			Description: "Builds the API",
+			Exec: &executable.ExecExecutableType{
+				Cmd:  "make",
+				Args: executable.ArgumentList{{EnvKey: "TAG", Flag: "tag", Default: "latest"}},
+			},
+		}
+		api.SetContext(wsName, wsDir, "", otherPath)
+		ctx.ExecutableCache.EXPECT().GetExecutableList(gomock.Any()).
+			Return(executable.ExecutableList{api}, nil).AnyTimes()
+		ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, ref executable.Ref) (*executable.Executable, error) {
+				if ref.Equals(api.Ref()) {
+					return api, nil
+				}
+				return nil, fmt.Errorf("%s not found", ref)
+			}).AnyTimes()
+
+		inReader, inWriter := io.Pipe()
+		outReader, outWriter := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			done <- lsp.NewServer(ctx.Ctx).Serve(inReader, outWriter)
+			_ = outWriter.Close()
+		}()
+		c = newClient(inWriter, outReader)
+		DeferCleanup(func() {
+			_ = inWriter.Close()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		var initResult map[string]any
+		c.call("initialize", map[string]any{}, &initResult)
+		Expect(initResult).To(HaveKey("capabilities"))
+		c.notify("textDocument/didOpen", map[string]any{
+			"textDocument": map[string]any{"uri": docURI, "languageId": "yaml", "version": 1, "text": docText},
+		})
+	})
+
+	It("should publish validation and ref resolution diagnostics", func() {
+		var result any
+		c.call("shutdown", nil, &result)
+		Expect(c.notifications).To(HaveLen(1))
+		var params struct {
+			URI         string           `json:"uri"`
+			Diagnostics []lsp.Diagnostic `json:"diagnostics"`
+		}
+		Expect(json.Unmarshal(c.notifications[0].Params, &params)).To(Succeed())
+		Expect(params.URI).To(Equal(docURI))
+		Expect(params.Diagnostics).To(HaveLen(2))
+		Expect(params.Diagnostics[0].Range.Start.Line).To(Equal(10))
+		Expect(params.Diagnostics[0].Message).To(ContainSubstring("must define at least one executable type"))
+		Expect(params.Diagnostics[1].Range.Start).To(Equal(lsp.Position{Line: 9, Character: 15}))
+		Expect(params.Diagnostics[1].Message).To(ContainSubstring("build missing"))
+	})
+
+	It("should report YAML errors", func() {
+		c.notify("textDocument/didChange", map[string]any{
+			"textDocument":   map[string]any{"uri": docURI, "version": 2},
+			"contentChanges": []map[string]any{{"text": "executables:\n  - verb: [\n"}},
+		})
+		var result any
+		c.call("shutdown", nil, &result)
+		Expect(c.notifications).To(HaveLen(2))
+		Expect(string(c.notifications[1].Params)).To(ContainSubstring(`"severity":1`))
+	})
+
+	It("should report malformed messages and keep serving", func() {
+		body := `{"jsonrpc": "2.0", "id": 5, "method": `
+		_, err := fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+		Expect(err).NotTo(HaveOccurred())
+
+		var result any
+		c.call("shutdown", nil, &result)
+		Expect(c.notifications).To(HaveLen(2))
+		Expect(c.notifications[1].Error).NotTo(BeNil())
+		Expect(c.notifications[1].Error.Code).To(Equal(-32700))
+	})
+
+	It("should complete verbs", func() {
+		var items []lsp.CompletionItem
+		c.call("textDocument/completion", position(docURI, 1, 12), &items)
+		Expect(items).To(ContainElement(HaveField("Label", "deploy")))
+		Expect(items[0].TextEdit.Range.Start).To(Equal(lsp.Position{Line: 1, Character: 10}))
+	})
+
+	It("should complete executable refs", func() {
+		var items []lsp.CompletionItem
+		c.call("textDocument/completion", position(docURI, 6, 15), &items)
+		labels := make([]string, 0)
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+		Expect(labels).To(ConsistOf("build default/api", "build default/broken", "deploy default/app"))
+	})
+
+	It("should complete the arg flags of the referenced executable", func() {
+		var items []lsp.CompletionItem
+		c.call("textDocument/completion", position(docURI, 8, 14), &items)
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Label).To(Equal("tag="))
+		Expect(items[0].Detail).To(Equal("TAG, default: latest"))
+	})
+
+	It("should go to the definition of a ref", func() {
+		var location lsp.Location
+		c.call("textDocument/definition", position(docURI, 6, 18), &location)
+		Expect(location.URI).To(Equal("file://" + otherPath))
+		Expect(location.Range.Start).To(Equal(lsp.Position{Line: 2, Character: 10}))
+	})
+
+	It("should show the documentation of referenced and defined executables", func() {
+		var hover lsp.Hover
+		c.call("textDocument/hover", position(docURI, 6, 18), &hover)
+		Expect(hover.Contents.Value).To(ContainSubstring("Builds the API"))
+
+		c.call("textDocument/hover", position(docURI, 2, 12), &hover)
+		Expect(hover.Contents.Value).To(ContainSubstring("Deploys the app"))
+	})
+})
@@ -0,0 +1,69 @@
+package lsp
+
+import (
+	"regexp"
+
+	"github.com/jahvon/flow/types/executable"
+)
+
+var refLineRegex = regexp.MustCompile(`^(\s*(?:-\s+)?ref:\s*["']?)([^"'#]*?)["']?\s*(?:#.*)?$`)
+
+// refAt returns the ref that is set on the line of the position.
+func (d *document) refAt(pos Position) (executable.Ref, Range, bool) {
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return "", Range{}, false
+	}
+	m := refLineRegex.FindStringSubmatch(d.lines[pos.Line])
+	if m == nil || m[2] == "" {
+		return "", Range{}, false
+	}
+	start := Position{Line: pos.Line, Character: len([]rune(m[1]))}
+	end := Position{Line: pos.Line, Character: start.Character + len([]rune(m[2]))}
+	return executable.Ref(m[2]), Range{Start: start, End: end}, true
+}
+
+func (s *Server) definition(doc *document, pos Position) *Location {
+	ref, _, found := doc.refAt(pos)
+	if !found {
+		return nil
+	}
+	e, err := s.resolveRef(doc, ref)
+	if err != nil || e == nil {
+		return nil
+	}
+	if _, node := doc.findExecutable(e.Ref()); node != nil {
+		if _, name := mappingValue(node, "name"); name != nil {
+			return &Location{URI: doc.uri, Range: nodeRange(name)}
+		}
+	}
+	return &Location{URI: pathToURI(e.FlowFilePath()), Range: executableRange(e.FlowFilePath(), e)}
+}
+
+// hover returns the documentation of the executable that is referenced on the line or defined at the position.
+func (s *Server) hover(doc *document, pos Position) *Hover {
+	if ref, rng, found := doc.refAt(pos); found {
+		e, err := s.resolveRef(doc, ref)
+		if err != nil || e == nil {
+			return nil
+		}
+		return &Hover{Contents: MarkupContent{Kind: markupKindMarkdown, Value: e.Markdown()}, Range: &rng}
+	}
+	if doc.flowFile == nil {
+		return nil
+	}
+	for i, node := range doc.executableNodes() {
+		if i >= len(doc.flowFile.Executables) {
+			break
+		}
+		for _, key := range []string{"verb", "name"} {
+			if _, value := mappingValue(node, key); value != nil && value.Line-1 == pos.Line {
+				rng := nodeRange(value)
+				return &Hover{
+					Contents: MarkupContent{Kind: markupKindMarkdown, Value: doc.flowFile.Executables[i].Markdown()},
+					Range:    &rng,
+				}
+			}
+		}
+	}
+	return nil
+}
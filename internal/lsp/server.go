@@ -0,0 +1,172 @@
+// Package lsp implements a Language Server Protocol server for flowfiles. It provides completion, go-to-definition,
+// hover documentation, and diagnostics using the executables from the flow cache.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const serverName = "flow"
+
+type Server struct {
+	ctx  *context.Context
+	docs map[string]*document
+	out  io.Writer
+}
+
+func NewServer(ctx *context.Context) *Server {
+	return &Server{ctx: ctx, docs: make(map[string]*document)}
+}
+
+// Serve reads requests from in and writes responses and notifications to out until the client sends the exit
+// notification or closes the input. Messages that can't be decoded are answered with an error response and skipped.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	reader := bufio.NewReader(in)
+	for {
+		req, err := readMessage(reader)
+		var msgErr *responseError
+		switch {
+		case errors.As(err, &msgErr):
+			// The ID of a message that can't be decoded is unknown, so the response has a null ID.
+			if err := writeMessage(out, &errorResponse{JSONRPC: jsonRPCVersion, Error: msgErr}); err != nil {
+				return err
+			}
+			continue
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		case req.Method == "exit":
+			return nil
+		}
+
+		result, respErr := s.handle(req)
+		if req.ID == nil {
+			if respErr != nil {
+				s.ctx.Logger.Errorf("%s failed: %s", req.Method, respErr.Message)
+			}
+			continue
+		}
+		if respErr != nil {
+			err = writeMessage(out, &errorResponse{JSONRPC: jsonRPCVersion, ID: req.ID, Error: respErr})
+		} else {
+			err = writeMessage(out, &response{JSONRPC: jsonRPCVersion, ID: req.ID, Result: result})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+//nolint:gocyclo
+func (s *Server) handle(req *request) (any, *responseError) {
+	switch req.Method {
+	case "initialize":
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				CompletionProvider: &completionOptions{TriggerCharacters: []string{" ", ":", "-", "/"}},
+				DefinitionProvider: true,
+				HoverProvider:      true,
+			},
+			ServerInfo: serverInfo{Name: serverName},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.open(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if len(params.ContentChanges) > 0 {
+			s.open(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didSave":
+		// Saved changes may add or remove executables that are referenced by other open flowfiles.
+		if err := s.ctx.ExecutableCache.Update(s.ctx.Logger); err != nil {
+			return nil, &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		for _, doc := range s.docs {
+			s.publishDiagnostics(doc.uri, s.diagnostics(doc))
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, params.TextDocument.URI)
+		s.publishDiagnostics(params.TextDocument.URI, []Diagnostic{})
+	case "textDocument/completion", "textDocument/definition", "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		doc, found := s.docs[params.TextDocument.URI]
+		if !found {
+			return nil, nil
+		}
+		switch req.Method {
+		case "textDocument/completion":
+			return s.completion(doc, params.Position), nil
+		case "textDocument/definition":
+			return s.definition(doc, params.Position), nil
+		default:
+			return s.hover(doc, params.Position), nil
+		}
+	default:
+		if req.ID != nil && !strings.HasPrefix(req.Method, "$/") {
+			return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) open(uri, text string) {
+	doc := newDocument(s.ctx, uri, text)
+	s.docs[uri] = doc
+	s.publishDiagnostics(uri, s.diagnostics(doc))
+}
+
+func (s *Server) publishDiagnostics(uri string, diagnostics []Diagnostic) {
+	err := writeMessage(s.out, &notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  "textDocument/publishDiagnostics",
+		Params:  &publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+	if err != nil {
+		s.ctx.Logger.Errorf("unable to publish diagnostics: %v", err)
+	}
+}
+
+// resolveRef finds the executable that a ref in the document points to. Refs are expanded the same way they are when
+// the executable is run, with the document's workspace used when the ref does not include one. Executables defined in
+// the document are found before they are saved to the cache.
+func (s *Server) resolveRef(doc *document, ref executable.Ref) (*executable.Executable, error) {
+	expanded := context.ExpandRefInWorkspace(s.ctx, ref, doc.workspace)
+	if err := expanded.Validate(); err != nil {
+		return nil, err
+	}
+	if e, _ := doc.findExecutable(expanded); e != nil {
+		return e, nil
+	}
+	return s.ctx.ExecutableCache.GetExecutableByRef(s.ctx.Logger, expanded)
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
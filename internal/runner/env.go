@@ -50,20 +50,24 @@ func ResolveParameterValue(
 		}
 		return val, nil
 	case param.SecretRef != "":
-		if err := vault.ValidateReference(param.SecretRef); err != nil {
-			return "", err
-		}
-		v := vault.NewVault(logger)
-		secret, err := v.GetSecret(param.SecretRef)
-		if err != nil {
-			return "", err
-		}
-		return secret.PlainTextString(), nil
+		return ResolveSecretValue(logger, param.SecretRef)
 	default:
 		return "", errors.New("failed to get value for parameter")
 	}
 }
 
+func ResolveSecretValue(logger io.Logger, secretRef string) (string, error) {
+	if err := vault.ValidateReference(secretRef); err != nil {
+		return "", err
+	}
+	v := vault.NewVault(logger)
+	secret, err := v.GetSecret(secretRef)
+	if err != nil {
+		return "", err
+	}
+	return secret.PlainTextString(), nil
+}
+
 func BuildEnvList(
 	logger io.Logger,
 	exec *executable.ExecutableEnvironment,
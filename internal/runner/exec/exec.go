@@ -66,9 +66,9 @@ func (r *execRunner) Exec(
 	case execSpec.Cmd != "" && execSpec.File != "":
 		return errors.New("cannot set both cmd and file")
 	case execSpec.Cmd != "":
-		return run.RunCmd(execSpec.Cmd, targetDir, envList, logMode, ctx.Logger, ctx.StdIn(), logFields)
+		return run.RunCmd(ctx.Ctx, execSpec.Cmd, targetDir, envList, logMode, ctx.Logger, ctx.StdIn(), logFields)
 	case execSpec.File != "":
-		return run.RunFile(execSpec.File, targetDir, envList, logMode, ctx.Logger, ctx.StdIn(), logFields)
+		return run.RunFile(ctx.Ctx, execSpec.File, targetDir, envList, logMode, ctx.Logger, ctx.StdIn(), logFields)
 	default:
 		return errors.New("unable to determine how e should be run")
 	}
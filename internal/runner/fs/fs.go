@@ -0,0 +1,71 @@
+package fs
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/internal/runner/prompt"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type fsRunner struct{}
+
+func NewRunner() runner.Runner {
+	return &fsRunner{}
+}
+
+func (r *fsRunner) Name() string {
+	return "fs"
+}
+
+func (r *fsRunner) IsCompatible(executable *executable.Executable) bool {
+	if executable == nil || executable.Fs == nil {
+		return false
+	}
+	return true
+}
+
+func (r *fsRunner) Exec(
+	ctx *context.Context,
+	e *executable.Executable,
+	_ engine.Engine,
+	inputEnv map[string]string,
+) error {
+	execEnv, err := runner.BuildEnvMap(ctx.Logger, e.Env(), inputEnv, runner.DefaultEnv(ctx, e))
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	// The paths and templates can use the process's environment and the env passed from a parent executable.
+	envMap := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, val, found := strings.Cut(kv, "="); found {
+			envMap[key] = val
+		}
+	}
+	maps.Copy(envMap, inputEnv)
+	maps.Copy(envMap, execEnv)
+	o := &operator{ctx: ctx, e: e, envMap: envMap}
+	for i, op := range e.Fs.Ops {
+		if err := o.run(op); err != nil {
+			return fmt.Errorf("%s operation %d failed: %w", op.Name(), i+1, err)
+		}
+	}
+	return nil
+}
+
+// confirmRemove asks the user to confirm the removal of the paths. Confirmation is assumed when the --yes flag is
+// used; otherwise, it cannot be given when there is no input to read the answer from.
+func confirmRemove(ctx *context.Context, paths []string) (bool, error) {
+	confirmed, err := prompt.Confirm(ctx, fmt.Sprintf("Remove %d path(s)?", len(paths)), strings.Join(paths, "\n"))
+	if errors.Is(err, prompt.ErrNoAnswer) {
+		return false, errors.New("removing files must be confirmed; set force or use the --yes flag")
+	}
+	return confirmed, err
+}
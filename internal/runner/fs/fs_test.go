@@ -0,0 +1,153 @@
+package fs_test
+
+import (
+	stdCtx "context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/fs"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestFs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fs Runner Suite")
+}
+
+var _ = Describe("Fs Runner", func() {
+	var (
+		ctx   *context.Context
+		fsRnr runner.Runner
+		wsDir string
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContext(stdCtx.Background(), GinkgoT())
+		fsRnr = fs.NewRunner()
+		wsDir = ctx.CurrentWorkspace.Location()
+	})
+
+	newExec := func(ops ...executable.FsOperation) *executable.Executable {
+		e := &executable.Executable{Verb: "exec", Name: "fs", Fs: &executable.FsExecutableType{Ops: ops}}
+		e.SetContext(ctx.CurrentWorkspace.AssignedName(), wsDir, "ns", filepath.Join(wsDir, "fs.flow"))
+		return e
+	}
+
+	writeFile := func(name, content string) {
+		path := filepath.Join(wsDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+
+	Context("IsCompatible", func() {
+		It("should return false when executable is nil", func() {
+			Expect(fsRnr.IsCompatible(nil)).To(BeFalse())
+		})
+
+		It("should return true when executable type is fs", func() {
+			Expect(fsRnr.IsCompatible(newExec())).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		It("should copy the paths that match the pattern into the directory", func() {
+			writeFile("assets/a.png", "a")
+			writeFile("assets/b.png", "b")
+			writeFile("assets/c.txt", "c")
+			e := newExec(executable.FsOperation{Copy: &executable.FsTransfer{Src: "assets/*.png", Dst: "//dist/assets"}})
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(filepath.Join(wsDir, "dist/assets/a.png")).To(BeAnExistingFile())
+			Expect(filepath.Join(wsDir, "dist/assets/b.png")).To(BeAnExistingFile())
+			Expect(filepath.Join(wsDir, "dist/assets/c.txt")).NotTo(BeAnExistingFile())
+		})
+
+		It("should move the file into an existing directory", func() {
+			writeFile("build/app", "bin")
+			Expect(os.MkdirAll(filepath.Join(wsDir, "bin"), 0750)).To(Succeed())
+			e := newExec(executable.FsOperation{Move: &executable.FsTransfer{Src: "build/app", Dst: "bin"}})
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(filepath.Join(wsDir, "bin/app")).To(BeAnExistingFile())
+			Expect(filepath.Join(wsDir, "build/app")).NotTo(BeAnExistingFile())
+		})
+
+		It("should create directories in the process's temporary directory", func() {
+			e := newExec(executable.FsOperation{Mkdir: &executable.FsPath{Path: "f:tmp/cache/$NAME"}})
+			Expect(fsRnr.Exec(ctx, e, nil, map[string]string{"NAME": "app"})).To(Succeed())
+			Expect(ctx.ProcessTmpDir).NotTo(BeEmpty())
+			defer os.RemoveAll(ctx.ProcessTmpDir)
+			Expect(filepath.Join(ctx.ProcessTmpDir, "cache/app")).To(BeADirectory())
+		})
+
+		It("should render the template with the env", func() {
+			writeFile("config.tmpl", "name: {{ .NAME | upper }}\n")
+			e := newExec(executable.FsOperation{Template: &executable.FsTransfer{Src: "config.tmpl", Dst: "out/config.yaml"}})
+			Expect(fsRnr.Exec(ctx, e, nil, map[string]string{"NAME": "app"})).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(wsDir, "out/config.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("name: APP\n"))
+		})
+
+		It("should replace an existing symbolic link", func() {
+			writeFile("v1/app", "v1")
+			writeFile("v2/app", "v2")
+			e := newExec(
+				executable.FsOperation{Symlink: &executable.FsTransfer{Src: "v1", Dst: "current"}},
+				executable.FsOperation{Symlink: &executable.FsTransfer{Src: "v2", Dst: "current"}},
+			)
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(wsDir, "current/app"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("v2"))
+		})
+
+		It("should set the mode of the paths that match the pattern", func() {
+			writeFile("scripts/build.sh", "")
+			writeFile("scripts/test.sh", "")
+			e := newExec(executable.FsOperation{Chmod: &executable.FsPath{Path: "scripts/*.sh", Mode: "0755"}})
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			for _, name := range []string{"build.sh", "test.sh"} {
+				info, err := os.Stat(filepath.Join(wsDir, "scripts", name))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0755)))
+			}
+		})
+
+		It("should remove the paths when forced", func() {
+			writeFile("logs/a.log", "")
+			writeFile("logs/b.log", "")
+			e := newExec(executable.FsOperation{Remove: &executable.FsPath{Path: "logs/*.log", Force: true}})
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(filepath.Join(wsDir, "logs/a.log")).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(wsDir, "logs")).To(BeADirectory())
+		})
+
+		It("should not remove the paths when the removal cannot be confirmed", func() {
+			writeFile("logs/a.log", "")
+			e := newExec(executable.FsOperation{Remove: &executable.FsPath{Path: "logs"}})
+			err := fsRnr.Exec(ctx, e, nil, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("remove operation 1 failed: removing files must be confirmed")))
+			Expect(filepath.Join(wsDir, "logs/a.log")).To(BeAnExistingFile())
+
+			GinkgoT().Setenv(executable.AssumeYesEnv, "true")
+			Expect(fsRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(filepath.Join(wsDir, "logs")).NotTo(BeAnExistingFile())
+		})
+
+		It("should stop at the first operation that fails", func() {
+			e := newExec(
+				executable.FsOperation{Copy: &executable.FsTransfer{Src: "missing/*", Dst: "dist/"}},
+				executable.FsOperation{Mkdir: &executable.FsPath{Path: "dist"}},
+			)
+			err := fsRnr.Exec(ctx, e, nil, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("copy operation 1 failed: no paths match missing/*")))
+			Expect(filepath.Join(wsDir, "dist")).NotTo(BeADirectory())
+		})
+	})
+})
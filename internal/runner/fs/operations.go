@@ -0,0 +1,255 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/internal/templates"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type operator struct {
+	ctx    *context.Context
+	e      *executable.Executable
+	envMap map[string]string
+}
+
+func (o *operator) run(op executable.FsOperation) error {
+	switch {
+	case op.Copy != nil:
+		return o.copy(op.Copy)
+	case op.Move != nil:
+		return o.move(op.Move)
+	case op.Mkdir != nil:
+		return o.mkdir(op.Mkdir)
+	case op.Remove != nil:
+		return o.remove(op.Remove)
+	case op.Template != nil:
+		return o.template(op.Template)
+	case op.Symlink != nil:
+		return o.symlink(op.Symlink)
+	case op.Chmod != nil:
+		return o.chmod(op.Chmod)
+	default:
+		return errors.New("no operation set")
+	}
+}
+
+func (o *operator) copy(t *executable.FsTransfer) error {
+	srcs, err := o.glob(t.Src)
+	if err != nil {
+		return err
+	} else if len(srcs) == 0 {
+		return fmt.Errorf("no paths match %s", t.Src)
+	}
+	dst, err := o.resolvePath(t.Dst)
+	if err != nil {
+		return err
+	}
+	intoDir := len(srcs) > 1 || isDirPath(t.Dst, dst)
+	for _, src := range srcs {
+		if _, err := os.Stat(src); err != nil {
+			return err
+		}
+		target := dst
+		if intoDir {
+			target = filepath.Join(dst, filepath.Base(src))
+		}
+		if err := os.MkdirAll(filepath.Dir(target), executable.DefaultDirMode); err != nil {
+			return err
+		}
+		o.ctx.Logger.Debugf("copying %s to %s", src, target)
+		if err := filesystem.CopyFile(src, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *operator) move(t *executable.FsTransfer) error {
+	src, err := o.resolvePath(t.Src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(src); err != nil {
+		return err
+	}
+	dst, err := o.resolvePath(t.Dst)
+	if err != nil {
+		return err
+	}
+	if isDirPath(t.Dst, dst) {
+		dst = filepath.Join(dst, filepath.Base(src))
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), executable.DefaultDirMode); err != nil {
+		return err
+	}
+	o.ctx.Logger.Debugf("moving %s to %s", src, dst)
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	// Renaming fails when the paths are on different devices, so the path is copied and then removed instead.
+	if err := filesystem.CopyFile(src, dst); err != nil {
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func (o *operator) mkdir(p *executable.FsPath) error {
+	path, err := o.resolvePath(p.Path)
+	if err != nil {
+		return err
+	}
+	mode, err := p.FileMode(executable.DefaultDirMode)
+	if err != nil {
+		return err
+	}
+	o.ctx.Logger.Debugf("creating %s", path)
+	return os.MkdirAll(path, mode)
+}
+
+func (o *operator) remove(p *executable.FsPath) error {
+	paths, err := o.glob(p.Path)
+	if err != nil {
+		return err
+	}
+	existing := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	if len(existing) == 0 {
+		o.ctx.Logger.Debugf("nothing to remove for %s", p.Path)
+		return nil
+	}
+	if !p.Force {
+		confirmed, err := confirmRemove(o.ctx, existing)
+		if err != nil {
+			return err
+		} else if !confirmed {
+			return errors.New("removal was not confirmed")
+		}
+	}
+	for _, path := range existing {
+		o.ctx.Logger.Debugf("removing %s", path)
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *operator) template(t *executable.FsTransfer) error {
+	src, err := o.resolvePath(t.Src)
+	if err != nil {
+		return err
+	}
+	dst, err := o.resolvePath(t.Dst)
+	if err != nil {
+		return err
+	}
+	if isDirPath(t.Dst, dst) {
+		dst = filepath.Join(dst, filepath.Base(src))
+	}
+	o.ctx.Logger.Debugf("rendering %s to %s", src, dst)
+	return templates.RenderFile(src, dst, o.envMap)
+}
+
+func (o *operator) symlink(t *executable.FsTransfer) error {
+	target, err := o.resolvePath(t.Src)
+	if err != nil {
+		return err
+	}
+	link, err := o.resolvePath(t.Dst)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Lstat(link); err == nil {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("%s already exists and is not a symbolic link", link)
+		}
+		if err := os.Remove(link); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(link), executable.DefaultDirMode); err != nil {
+		return err
+	}
+	o.ctx.Logger.Debugf("linking %s to %s", link, target)
+	return os.Symlink(target, link)
+}
+
+func (o *operator) chmod(p *executable.FsPath) error {
+	paths, err := o.glob(p.Path)
+	if err != nil {
+		return err
+	} else if len(paths) == 0 {
+		return fmt.Errorf("no paths match %s", p.Path)
+	}
+	mode, err := p.FileMode(0)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		o.ctx.Logger.Debugf("setting the mode of %s to %s", path, mode)
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePath expands the path with the same rules as the executable `dir` field. Paths in the process's temporary
+// directory are prefixed with `f:tmp/`.
+func (o *operator) resolvePath(path string) (string, error) {
+	if path == executable.TmpDirLabel || strings.HasPrefix(path, executable.TmpDirLabel+"/") {
+		tmpDir, _, err := executable.Directory(executable.TmpDirLabel).ExpandDirectory(
+			o.ctx.Logger, o.e.WorkspacePath(), o.e.FlowFilePath(), o.ctx.ProcessTmpDir, o.envMap,
+		)
+		if err != nil {
+			return "", err
+		}
+		o.ctx.ProcessTmpDir = tmpDir
+		rest := os.Expand(strings.TrimPrefix(path, executable.TmpDirLabel), func(key string) string {
+			return o.envMap[key]
+		})
+		return filepath.Join(tmpDir, rest), nil
+	}
+	resolved, _, err := executable.Directory(path).ExpandDirectory(
+		o.ctx.Logger, o.e.WorkspacePath(), o.e.FlowFilePath(), o.ctx.ProcessTmpDir, o.envMap,
+	)
+	return resolved, err
+}
+
+// glob resolves the path and returns the paths that match it. Paths without a pattern are returned as they are,
+// whether they exist or not.
+func (o *operator) glob(path string) ([]string, error) {
+	resolved, err := o.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.ContainsAny(resolved, "*?[") {
+		return []string{resolved}, nil
+	}
+	matches, err := filepath.Glob(resolved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %s", path)
+	}
+	return matches, nil
+}
+
+// isDirPath returns true if the path ends with a separator or is an existing directory.
+func isDirPath(path, resolved string) bool {
+	if strings.HasSuffix(path, "/") {
+		return true
+	}
+	info, err := os.Stat(resolved)
+	return err == nil && info.IsDir()
+}
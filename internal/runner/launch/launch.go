@@ -1,8 +1,11 @@
 package launch
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/atotto/clipboard"
+	"github.com/jahvon/tuikit/io"
 	"github.com/pkg/errors"
 
 	"github.com/jahvon/flow/internal/context"
@@ -58,8 +61,34 @@ func (r *launchRunner) Exec(
 		envMap,
 	)
 
-	if launchSpec.App != "" {
-		return open.OpenWith(launchSpec.App, targetURI, launchSpec.Wait)
+	if launchSpec.App == "" && open.Headless() {
+		launchHeadless(ctx.Logger, targetURI)
+		return nil
 	}
-	return open.Open(targetURI, launchSpec.Wait)
+
+	appArgs := make([]string, 0, len(launchSpec.AppArgs))
+	for _, arg := range launchSpec.AppArgs {
+		appArgs = append(appArgs, os.ExpandEnv(arg))
+	}
+	envList := make([]string, 0, len(envMap))
+	for k, v := range envMap {
+		envList = append(envList, fmt.Sprintf("%s=%s", k, v))
+	}
+	return open.Launch(targetURI, open.LaunchOptions{
+		App:  launchSpec.App,
+		Args: appArgs,
+		Env:  envList,
+		Wait: launchSpec.Wait,
+	})
+}
+
+// launchHeadless prints the URI so that it can be opened manually when there is no display available.
+func launchHeadless(logger io.Logger, uri string) {
+	logger.Warnf("Unable to open the URI; no display or xdg-open is available")
+	logger.PlainTextInfo(uri)
+	if err := clipboard.WriteAll(uri); err != nil {
+		logger.Debugf("unable to copy URI to clipboard: %v", err)
+		return
+	}
+	logger.PlainTextSuccess("copied URI to clipboard")
 }
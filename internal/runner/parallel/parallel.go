@@ -51,11 +51,17 @@ func (r *parallelRunner) Exec(
 		if err != nil {
 			return err
 		}
-		defer str.Close()
 		if err := str.CreateBucket(store.EnvironmentBucket()); err != nil {
+			_ = str.Close()
 			return err
 		}
-		return handleExec(ctx, e, eng, parallelSpec, inputEnv, str)
+		dm, err := str.GetAll()
+		// The store is closed before running the child executables so that they are able to open it.
+		_ = str.Close()
+		if err != nil {
+			return err
+		}
+		return handleExec(ctx, e, eng, parallelSpec, inputEnv, dm)
 	}
 
 	return fmt.Errorf("no parallel executables to run")
@@ -65,7 +71,7 @@ func handleExec(
 	ctx *context.Context, parent *executable.Executable,
 	eng engine.Engine,
 	parallelSpec *executable.ParallelExecutableType, promptedEnv map[string]string,
-	str store.Store,
+	storeData map[string]string,
 ) error {
 	groupCtx, cancel := stdCtx.WithCancel(ctx.Ctx)
 	defer cancel()
@@ -76,13 +82,10 @@ func handleExec(
 	}
 	group.SetLimit(limit)
 
-	dm, err := str.GetAll()
-	if err != nil {
-		return err
-	}
-	dataMap := expr.ExpressionEnv(ctx, parent, dm, promptedEnv)
+	dataMap := expr.ExpressionEnv(ctx, parent, storeData, promptedEnv)
 
 	var execs []engine.Exec
+	var services []*executable.Executable
 	for i, refConfig := range parallelSpec.Execs {
 		if refConfig.If != "" {
 			if truthy, err := expr.IsTruthy(refConfig.If, &dataMap); err != nil {
@@ -105,9 +108,12 @@ func handleExec(
 		default:
 			return errors.New("parallel executable must have a ref or cmd")
 		}
+		if exec.Service != nil {
+			services = append(services, exec)
+		}
 
 		execPromptedEnv := make(map[string]string)
-		maps.Copy(promptedEnv, execPromptedEnv)
+		maps.Copy(execPromptedEnv, promptedEnv)
 		if len(refConfig.Args) > 0 {
 			a, err := argUtils.ProcessArgs(exec, refConfig.Args, execPromptedEnv)
 			if err != nil {
@@ -137,6 +143,7 @@ func handleExec(
 		engine.WithFailFast(parent.Parallel.FailFast),
 		engine.WithMaxThreads(parent.Parallel.MaxThreads),
 	)
+	runner.StopServices(ctx, services)
 	if results.HasErrors() {
 		return errors.New(results.String())
 	}
@@ -0,0 +1,328 @@
+package prompt
+
+import (
+	stdCtx "context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/internal/context"
+	flowIO "github.com/jahvon/flow/internal/io"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const confirmKey = "FLOW_CONFIRMED"
+
+// ErrNoAnswer is returned when a question cannot be answered because there is no input to read the answer from.
+var ErrNoAnswer = errors.New("no answer")
+
+// Ask returns the answers to the prompt's questions by key. Answers are taken from the answers file first.
+// The remaining questions are answered with their defaults when confirmations are assumed; otherwise, they are
+// displayed in a form.
+func Ask(ctx *context.Context, prompt *executable.PromptConfig) (map[string]string, error) {
+	fileAnswers, err := loadAnswersFile()
+	if err != nil {
+		return nil, err
+	}
+	answers := make(map[string]string)
+	var pending []executable.PromptField
+	for _, field := range prompt.Fields {
+		answer, found := fileAnswers[field.Key]
+		switch {
+		case found:
+			if err := field.ValidateAnswer(answer); err != nil {
+				return nil, errors.Wrap(err, "invalid answer in answers file")
+			}
+			answers[field.Key] = normalizeAnswer(field, answer)
+		case executable.AssumeYes():
+			answer, err := defaultAnswer(field)
+			if err != nil {
+				return nil, err
+			}
+			answers[field.Key] = answer
+		default:
+			pending = append(pending, field)
+		}
+	}
+	if len(pending) == 0 {
+		return answers, nil
+	}
+
+	var timeout time.Duration
+	if prompt.Timeout != nil {
+		timeout = *prompt.Timeout
+	}
+	formAnswers, err := askFields(ctx, pending, timeout)
+	if err != nil {
+		return nil, err
+	}
+	for key, answer := range formAnswers {
+		answers[key] = answer
+	}
+	return answers, nil
+}
+
+// Confirm asks the user to confirm the action described by the title. Confirmation is assumed when the --yes flag is
+// used. An error wrapping ErrNoAnswer is returned when the input is not a terminal and has no answer.
+func Confirm(ctx *context.Context, title, description string) (bool, error) {
+	if executable.AssumeYes() {
+		return true, nil
+	}
+	field := executable.PromptField{
+		Key:         confirmKey,
+		Type:        executable.PromptFieldTypeConfirm,
+		Title:       title,
+		Description: description,
+	}
+	answers, err := askFields(ctx, []executable.PromptField{field}, 0)
+	if err != nil {
+		return false, err
+	}
+	return answers[confirmKey] == "true", nil
+}
+
+// loadAnswersFile reads the answers file, if one is set. Lists are converted to comma-separated answers.
+func loadAnswersFile() (map[string]string, error) {
+	path := os.Getenv(executable.AnswersFileEnv)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read answers file")
+	}
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "unable to parse answers file")
+	}
+	answers := make(map[string]string, len(raw))
+	for key, val := range raw {
+		switch v := val.(type) {
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprintf("%v", item))
+			}
+			answers[key] = strings.Join(items, ",")
+		case nil:
+			answers[key] = ""
+		default:
+			answers[key] = fmt.Sprintf("%v", v)
+		}
+	}
+	return answers, nil
+}
+
+func defaultAnswer(field executable.PromptField) (string, error) {
+	answer := field.Default
+	if answer == "" && field.Type == executable.PromptFieldTypeConfirm && executable.AssumeYes() {
+		answer = "true"
+	}
+	if err := field.ValidateAnswer(answer); err != nil {
+		return "", errors.Wrap(err, "no default answer")
+	}
+	return normalizeAnswer(field, answer), nil
+}
+
+func normalizeAnswer(field executable.PromptField, answer string) string {
+	//nolint:exhaustive
+	switch field.Type {
+	case executable.PromptFieldTypeConfirm:
+		confirmed, _ := executable.ParseConfirmAnswer(answer)
+		return strconv.FormatBool(confirmed)
+	case executable.PromptFieldTypeMultiselect:
+		return strings.Join(splitOptions(answer), ",")
+	default:
+		return answer
+	}
+}
+
+func splitOptions(answer string) []string {
+	var opts []string
+	for _, opt := range strings.Split(answer, ",") {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+// askFields displays a form with the fields. When the input is not a terminal, one answer is read per line instead.
+// If the timeout is reached before the form is submitted, the default answers are used.
+func askFields(
+	ctx *context.Context,
+	fields []executable.PromptField,
+	timeout time.Duration,
+) (map[string]string, error) {
+	in := ctx.StdIn()
+	if in == nil || !term.IsTerminal(int(in.Fd())) {
+		return readAnswers(in, fields)
+	}
+
+	// The tuikit form does not have select and multiselect fields, so the form is built with huh directly.
+	values := make([]*fieldValue, 0, len(fields))
+	huhFields := make([]huh.Field, 0, len(fields))
+	for _, field := range fields {
+		value := &fieldValue{}
+		values = append(values, value)
+		huhFields = append(huhFields, formField(field, value))
+	}
+	theme := flowIO.Theme(ctx.Config.Theme.String())
+	form := huh.NewForm(huh.NewGroup(huhFields...)).
+		WithTheme(theme.HuhTheme()).
+		WithInput(in).
+		WithOutput(ctx.StdOut()).
+		WithAccessible(os.Getenv("TUI_ACCESSIBLE") != "")
+	formCtx := ctx.Ctx
+	if timeout > 0 {
+		var cancel stdCtx.CancelFunc
+		formCtx, cancel = stdCtx.WithTimeout(ctx.Ctx, timeout)
+		defer cancel()
+	}
+	err := form.RunWithContext(formCtx)
+	switch {
+	case errors.Is(err, huh.ErrTimeout) && ctx.Ctx.Err() == nil:
+		ctx.Logger.Warnf("prompt timed out after %s; using the default answers", timeout)
+		answers := make(map[string]string, len(fields))
+		for _, field := range fields {
+			if answers[field.Key], err = defaultAnswer(field); err != nil {
+				return nil, err
+			}
+		}
+		return answers, nil
+	case errors.Is(err, huh.ErrUserAborted), errors.Is(err, huh.ErrTimeout):
+		return nil, errors.New("prompt cancelled")
+	case err != nil:
+		return nil, err
+	}
+
+	answers := make(map[string]string, len(fields))
+	for i, field := range fields {
+		answer := values[i].answer(field)
+		if answer == "" {
+			if answers[field.Key], err = defaultAnswer(field); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := field.ValidateAnswer(answer); err != nil {
+			return nil, err
+		}
+		answers[field.Key] = normalizeAnswer(field, answer)
+	}
+	return answers, nil
+}
+
+// fieldValue holds the value of a form field while the form is displayed.
+type fieldValue struct {
+	text      string
+	confirmed bool
+	selected  []string
+}
+
+func (v *fieldValue) answer(field executable.PromptField) string {
+	//nolint:exhaustive
+	switch field.Type {
+	case executable.PromptFieldTypeConfirm:
+		return strconv.FormatBool(v.confirmed)
+	case executable.PromptFieldTypeMultiselect:
+		return strings.Join(v.selected, ",")
+	default:
+		return strings.TrimSpace(v.text)
+	}
+}
+
+// formField converts the prompt field to a form field that sets the value. The field starts with its default answer.
+func formField(field executable.PromptField, value *fieldValue) huh.Field {
+	title := field.Title
+	if title == "" {
+		title = field.Key
+	}
+	//nolint:exhaustive
+	switch field.Type {
+	case executable.PromptFieldTypeConfirm:
+		value.confirmed, _ = executable.ParseConfirmAnswer(field.Default)
+		return huh.NewConfirm().Key(field.Key).Title(title).Description(field.Description).Value(&value.confirmed)
+	case executable.PromptFieldTypeSelect:
+		value.text = field.Default
+		return huh.NewSelect[string]().Key(field.Key).Title(title).Description(field.Description).
+			Value(&value.text).Options(huh.NewOptions(field.Options...)...)
+	case executable.PromptFieldTypeMultiselect:
+		value.selected = splitOptions(field.Default)
+		return huh.NewMultiSelect[string]().Key(field.Key).Title(title).Description(field.Description).
+			Value(&value.selected).Options(huh.NewOptions(field.Options...)...).
+			Validate(func(selected []string) error {
+				return field.ValidateAnswer(strings.Join(selected, ","))
+			})
+	default:
+		mode := huh.EchoModeNormal
+		if field.Type == executable.PromptFieldTypeMasked {
+			mode = huh.EchoModePassword
+		}
+		return huh.NewInput().Key(field.Key).Title(title).Description(field.Description).
+			EchoMode(mode).Prompt("> ").Placeholder(field.Default).Value(&value.text).
+			Validate(func(answer string) error {
+				if answer = strings.TrimSpace(answer); answer == "" {
+					answer = field.Default
+				}
+				return field.ValidateAnswer(answer)
+			})
+	}
+}
+
+// readAnswers reads one answer per line from the input. Empty lines are answered with the default.
+func readAnswers(in *os.File, fields []executable.PromptField) (map[string]string, error) {
+	if in == nil {
+		return nil, fmt.Errorf("%w: no input available to answer prompt", ErrNoAnswer)
+	}
+	answers := make(map[string]string, len(fields))
+	for _, field := range fields {
+		line, err := readLine(in)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(err, "unable to read answer")
+		} else if line == "" && errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf(
+				"%w for %s; use --yes or --answers-file to run without a terminal", ErrNoAnswer, field.Key,
+			)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			if answers[field.Key], err = defaultAnswer(field); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := field.ValidateAnswer(answer); err != nil {
+			return nil, err
+		}
+		answers[field.Key] = normalizeAnswer(field, answer)
+	}
+	return answers, nil
+}
+
+// readLine reads a line from the input one byte at a time, so that the input after the line is left for the prompts
+// and executables that follow.
+func readLine(in io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := in.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package prompt_test
+
+import (
+	stdCtx "context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner/prompt"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestPrompt(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prompt Suite")
+}
+
+var _ = Describe("Confirm", func() {
+	var ctx *context.Context
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT()).Ctx
+	})
+
+	setInput := func(input string) {
+		in, err := os.CreateTemp(GinkgoT().TempDir(), "input")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = in.WriteString(input)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = in.Seek(0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		ctx.SetIO(in, ctx.StdOut())
+	}
+
+	It("should be confirmed when confirmations are assumed", func() {
+		GinkgoT().Setenv(executable.AssumeYesEnv, "true")
+		setInput("")
+		Expect(prompt.Confirm(ctx, "Continue?", "")).To(BeTrue())
+	})
+
+	It("should read the answer from piped input", func() {
+		setInput("yes\nno\n")
+		Expect(prompt.Confirm(ctx, "Continue?", "")).To(BeTrue())
+		Expect(prompt.Confirm(ctx, "Continue?", "")).To(BeFalse())
+	})
+
+	It("should return ErrNoAnswer when there is no answer", func() {
+		setInput("")
+		_, err := prompt.Confirm(ctx, "Continue?", "")
+		Expect(err).To(MatchError(prompt.ErrNoAnswer))
+	})
+})
@@ -0,0 +1,200 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/internal/runner/request"
+	"github.com/jahvon/flow/internal/services/expr"
+	"github.com/jahvon/flow/internal/services/store"
+	argUtils "github.com/jahvon/flow/internal/utils/args"
+	execUtils "github.com/jahvon/flow/internal/utils/executables"
+	"github.com/jahvon/flow/types/executable"
+)
+
+var builtinDataKeys = []string{"data", "env", "store", "ctx"}
+
+// templateData returns the data that the template is executed with. The merged template data is available
+// under `.data` and at the top level, alongside the env map (`.env`), the process store (`.store`) and the
+// flow context (`.ctx`). Top-level data keys that collide with one of these are only available under `.data`.
+func templateData(
+	ctx *context.Context,
+	e *executable.Executable,
+	eng engine.Engine,
+	dir string,
+	envMap map[string]string,
+) (map[string]interface{}, error) {
+	renderSpec := e.Render
+	data := make(map[string]interface{})
+	files := renderSpec.TemplateDataFiles
+	if renderSpec.TemplateDataFile != "" {
+		files = append([]string{renderSpec.TemplateDataFile}, files...)
+	}
+	for _, file := range files {
+		fileData, err := readDataFile(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		mergeData(data, fileData)
+	}
+	mergeData(data, renderSpec.Data)
+
+	for _, source := range renderSpec.DataFrom {
+		val, err := sourceData(ctx, e, eng, source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load data for %s", source.Key)
+		}
+		data[source.Key] = val
+	}
+
+	// The store is read after the data sources are run so that values they set are included.
+	storeData, err := processStoreData()
+	if err != nil {
+		return nil, err
+	}
+	exprEnv := expr.ExpressionEnv(ctx, e, storeData, envMap)
+
+	tmplData := make(map[string]interface{}, len(data)+len(builtinDataKeys))
+	maps.Copy(tmplData, data)
+	for _, key := range builtinDataKeys {
+		if _, found := data[key]; found {
+			ctx.Logger.Warnf("template data key %[1]s is shadowed by the built-in .%[1]s; use .data.%[1]s instead", key)
+		}
+	}
+	tmplData["data"] = data
+	tmplData["env"] = envMap
+	tmplData["store"] = storeData
+	tmplData["ctx"] = map[string]interface{}{
+		"workspace":     exprEnv.Ctx.Workspace,
+		"namespace":     exprEnv.Ctx.Namespace,
+		"workspacePath": exprEnv.Ctx.WorkspacePath,
+		"flowFilePath":  exprEnv.Ctx.FlowFilePath,
+		"flowFileDir":   exprEnv.Ctx.FlowFileDir,
+		"os":            exprEnv.OS,
+		"arch":          exprEnv.Arch,
+	}
+	return tmplData, nil
+}
+
+// mergeData deep-merges the src map into the dst map. Nested maps are copied so that src is never modified.
+func mergeData(dst, src map[string]interface{}) {
+	for key, val := range src {
+		srcMap, srcIsMap := val.(map[string]interface{})
+		if !srcIsMap {
+			dst[key] = val
+			continue
+		}
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if !dstIsMap {
+			dstMap = make(map[string]interface{}, len(srcMap))
+			dst[key] = dstMap
+		}
+		mergeData(dstMap, srcMap)
+	}
+}
+
+func processStoreData() (map[string]string, error) {
+	str, err := store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	defer str.Close()
+	if err := str.CreateBucket(store.EnvironmentBucket()); err != nil {
+		return nil, err
+	}
+	return str.GetAll()
+}
+
+// sourceData runs the executable of the data source and returns its parsed output. The response body is used
+// for request executables.
+func sourceData(
+	ctx *context.Context,
+	parent *executable.Executable,
+	eng engine.Engine,
+	source executable.RenderDataSource,
+) (interface{}, error) {
+	exec, err := execUtils.ExecutableForRef(ctx, source.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if exec.Ref().Equals(parent.Ref()) {
+		return nil, fmt.Errorf("render executable cannot reference itself")
+	}
+	env := make(map[string]string)
+	if len(source.Args) > 0 {
+		if env, err = argUtils.ProcessArgs(exec, source.Args, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var output string
+	if exec.Request != nil {
+		output, err = request.Fetch(ctx, exec, env)
+	} else {
+		output, err = captureOutput(ctx, exec, eng, env)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseOutput(output), nil
+}
+
+// captureOutput runs the executable and returns what it wrote to stdout.
+func captureOutput(
+	ctx *context.Context,
+	exec *executable.Executable,
+	eng engine.Engine,
+	env map[string]string,
+) (string, error) {
+	logger := &captureLogger{Logger: ctx.Logger}
+	ctx.Logger = logger
+	defer func() { ctx.Logger = logger.Logger }()
+	if err := runner.Exec(ctx, exec, eng, env); err != nil {
+		return "", err
+	}
+	return logger.out.String(), nil
+}
+
+// parseOutput returns the output parsed as JSON or YAML. The trimmed output is returned if it cannot be parsed.
+func parseOutput(output string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(output), &parsed); err == nil {
+		return parsed
+	}
+	if err := yaml.Unmarshal([]byte(output), &parsed); err == nil {
+		if _, isStr := parsed.(string); !isStr && parsed != nil {
+			return parsed
+		}
+	}
+	return strings.TrimSpace(output)
+}
+
+// captureLogger records the plain text output of an executable while all other logs are passed through.
+type captureLogger struct {
+	io.Logger
+	out strings.Builder
+}
+
+func (l *captureLogger) Print(data string) {
+	l.out.WriteString(data)
+}
+
+func (l *captureLogger) Println(data string) {
+	l.out.WriteString(data + "\n")
+}
+
+// LogMode always returns the text mode so that the output of the executable is written with Println.
+func (l *captureLogger) LogMode() io.LogMode {
+	return io.Text
+}
+
+func (l *captureLogger) SetMode(io.LogMode) {}
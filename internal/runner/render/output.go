@@ -0,0 +1,156 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+
+	"github.com/jahvon/glamour"
+	"github.com/jahvon/tuikit/views"
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/term"
+
+	"github.com/jahvon/flow/internal/context"
+	flowIO "github.com/jahvon/flow/internal/io"
+	"github.com/jahvon/flow/internal/io/viewer"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	defaultWordWrap = 80
+)
+
+const htmlDocument = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`
+
+// outputFor returns the output of the render executable. The TUI output falls back to stdout when the
+// interactive UI is disabled.
+func outputFor(ctx *context.Context, spec *executable.RenderExecutableType) executable.RenderExecutableTypeOutput {
+	output := spec.Output
+	if output != "" && output != executable.RenderExecutableTypeOutputTui {
+		return output
+	}
+	if !ctx.ShowTUI() {
+		return executable.RenderExecutableTypeOutputStdout
+	}
+	return executable.RenderExecutableTypeOutputTui
+}
+
+// contentWriter displays or writes the rendered content to the output of the render executable.
+type contentWriter struct {
+	ctx      *context.Context
+	output   executable.RenderExecutableTypeOutput
+	renderAs executable.ViewFormat
+	filename string
+	path     string
+}
+
+func (w *contentWriter) write(content string) error {
+	if w.renderAs == "" || w.renderAs == executable.ViewFormatMarkdown {
+		return w.writeMarkdown(content)
+	}
+	switch w.output {
+	case executable.RenderExecutableTypeOutputStdout, executable.RenderExecutableTypeOutputFile:
+		formatted, err := viewer.Format(w.renderAs, w.filename, content)
+		if err != nil {
+			return errors.Wrapf(err, "unable to render %s as %s", w.filename, w.renderAs)
+		}
+		if w.output == executable.RenderExecutableTypeOutputStdout {
+			_, err = fmt.Fprint(w.ctx.StdOut(), formatted)
+			return err
+		}
+		return w.writeFile([]byte(formatted))
+	default:
+		view, err := viewer.NewView(w.ctx.TUIContainer.RenderState(), w.renderAs, w.filename, content)
+		if err != nil {
+			return errors.Wrapf(err, "unable to render %s as %s", w.filename, w.renderAs)
+		}
+		w.ctx.TUIContainer.SetState("file", w.filename)
+		return w.ctx.TUIContainer.SetView(view)
+	}
+}
+
+func (w *contentWriter) writeMarkdown(content string) error {
+	switch w.output {
+	case executable.RenderExecutableTypeOutputStdout:
+		return printMarkdown(w.ctx, content)
+	case executable.RenderExecutableTypeOutputFile:
+		return w.writeFile([]byte(content))
+	case executable.RenderExecutableTypeOutputHtml:
+		html, err := markdownToHTML(w.filename, []byte(content))
+		if err != nil {
+			return err
+		}
+		return w.writeFile(html)
+	default:
+		w.ctx.TUIContainer.SetState("file", w.filename)
+		return w.ctx.TUIContainer.SetView(views.NewMarkdownView(w.ctx.TUIContainer.RenderState(), content))
+	}
+}
+
+func (w *contentWriter) writeFile(content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0750); err != nil {
+		return errors.Wrap(err, "unable to create output directory")
+	}
+	if err := os.WriteFile(w.path, content, 0600); err != nil {
+		return errors.Wrapf(err, "unable to write rendered content to %s", w.path)
+	}
+	w.ctx.Logger.Infof("Rendered content written to %s", w.path)
+	return nil
+}
+
+// printMarkdown prints the markdown to stdout styled with the configured theme.
+func printMarkdown(ctx *context.Context, content string) error {
+	mdStyles, err := flowIO.Theme(ctx.Config.Theme.String()).MarkdownStyleJSON()
+	if err != nil {
+		return errors.Wrap(err, "unable to load markdown styles")
+	}
+	width := defaultWordWrap
+	if w, _, err := term.GetSize(int(ctx.StdOut().Fd())); err == nil && w > 0 { //nolint:gosec
+		width = w
+	}
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStylesFromJSONBytes([]byte(mdStyles)),
+		glamour.WithPreservedNewLines(),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to create markdown renderer")
+	}
+	styled, err := renderer.Render(content)
+	if err != nil {
+		return errors.Wrap(err, "unable to render markdown")
+	}
+	_, err = fmt.Fprint(ctx.StdOut(), styled)
+	return err
+}
+
+func outputPath(dir, file string, envMap map[string]string) string {
+	path := os.Expand(file, func(key string) string { return envMap[key] })
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Clean(filepath.Join(dir, path))
+}
+
+// markdownToHTML converts the markdown to a standalone HTML document.
+func markdownToHTML(title string, content []byte) ([]byte, error) {
+	var body bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := md.Convert(content, &body); err != nil {
+		return nil, errors.Wrap(err, "unable to convert markdown to html")
+	}
+	return []byte(fmt.Sprintf(htmlDocument, html.EscapeString(title), body.String())), nil
+}
@@ -10,7 +10,6 @@ import (
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
-	"github.com/jahvon/tuikit/views"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -40,13 +39,9 @@ func (r *renderRunner) IsCompatible(executable *executable.Executable) bool {
 func (r *renderRunner) Exec(
 	ctx *context.Context,
 	e *executable.Executable,
-	_ engine.Engine,
+	eng engine.Engine,
 	inputEnv map[string]string,
 ) error {
-	if !ctx.Config.ShowTUI() {
-		return fmt.Errorf("unable to render when interactive mode is disabled")
-	}
-
 	renderSpec := e.Render
 	if err := runner.SetEnv(ctx.Logger, e.Env(), inputEnv); err != nil {
 		return errors.Wrap(err, "unable to set parameters to env")
@@ -69,28 +64,50 @@ func (r *renderRunner) Exec(
 	}
 
 	contentFile := filepath.Clean(filepath.Join(targetDir, renderSpec.TemplateFile))
-	var templateData map[string]interface{}
-	if renderSpec.TemplateDataFile != "" {
-		templateData, err = readDataFile(targetDir, renderSpec.TemplateDataFile)
+	renderContent := func() (string, error) {
+		tmplData, err := templateData(ctx, e, eng, targetDir, envMap)
+		if err != nil {
+			return "", err
+		}
+		tmpl, err := template.New(filepath.Base(renderSpec.TemplateFile)).
+			Funcs(sprig.TxtFuncMap()).
+			ParseFiles(contentFile)
 		if err != nil {
-			return err
+			return "", errors.Wrapf(err, "unable to parse template file %s", contentFile)
 		}
+		var buff bytes.Buffer
+		if err = tmpl.Execute(&buff, tmplData); err != nil {
+			return "", errors.Wrapf(err, "unable to execute template file %s", contentFile)
+		}
+		return buff.String(), nil
 	}
 
-	tmpl, err := template.New(filepath.Base(renderSpec.TemplateFile)).Funcs(sprig.TxtFuncMap()).ParseFiles(contentFile)
-	if err != nil {
-		return errors.Wrapf(err, "unable to parse template file %s", contentFile)
+	ctx.Logger.Infof("Rendering content from file %s", contentFile)
+	out := &contentWriter{
+		ctx:      ctx,
+		output:   outputFor(ctx, renderSpec),
+		renderAs: renderSpec.RenderAs,
+		filename: filepath.Base(contentFile),
 	}
-
-	var buff bytes.Buffer
-	if err = tmpl.Execute(&buff, templateData); err != nil {
-		return errors.Wrapf(err, "unable to execute template file %s", contentFile)
+	if out.output == executable.RenderExecutableTypeOutputFile ||
+		out.output == executable.RenderExecutableTypeOutputHtml {
+		out.path = outputPath(targetDir, renderSpec.OutputFile, envMap)
+	}
+	if out.output == executable.RenderExecutableTypeOutputTui && !ctx.TUIContainer.Ready() {
+		if err := ctx.TUIContainer.Start(); err != nil {
+			return errors.Wrap(err, "unable to start the terminal UI")
+		}
+		defer ctx.TUIContainer.WaitForExit()
 	}
 
-	ctx.Logger.Infof("Rendering content from file %s", contentFile)
-	filename := filepath.Base(contentFile)
-	ctx.TUIContainer.SetState("file", filename)
-	return ctx.TUIContainer.SetView(views.NewMarkdownView(ctx.TUIContainer.RenderState(), buff.String()))
+	if renderSpec.Watch {
+		return watchContent(ctx, out, watchedFiles(targetDir, contentFile, renderSpec), renderContent)
+	}
+	content, err := renderContent()
+	if err != nil {
+		return err
+	}
+	return out.write(content)
 }
 
 func readDataFile(dir, path string) (map[string]interface{}, error) {
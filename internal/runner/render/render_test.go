@@ -0,0 +1,320 @@
+package render_test
+
+import (
+	stdCtx "context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/engine/mocks"
+	execRunner "github.com/jahvon/flow/internal/runner/exec"
+	"github.com/jahvon/flow/internal/runner/render"
+	"github.com/jahvon/flow/internal/services/store"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestRender(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Render Suite")
+}
+
+var _ = Describe("Render Runner", func() {
+	var (
+		renderRnr  runner.Runner
+		ctx        *testUtils.ContextWithMocks
+		mockEngine *mocks.MockEngine
+		wsDir      string
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		renderRnr = render.NewRunner()
+		mockEngine = mocks.NewMockEngine(gomock.NewController(GinkgoT()))
+		wsDir = ctx.Ctx.CurrentWorkspace.Location()
+		Expect(os.WriteFile(
+			filepath.Join(wsDir, "template.md"), []byte("# {{ .title }}\n\n| a | b |\n|---|---|\n| 1 | 2 |\n"), 0600,
+		)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(wsDir, "data.yaml"), []byte("title: Report\n"), 0600)).To(Succeed())
+	})
+
+	Context("Name", func() {
+		It("should return the correct runner name", func() {
+			Expect(renderRnr.Name()).To(Equal("render"))
+		})
+	})
+
+	Context("IsCompatible", func() {
+		It("should return false when executable is nil", func() {
+			Expect(renderRnr.IsCompatible(nil)).To(BeFalse())
+		})
+
+		It("should return true when executable type is render", func() {
+			exec := &executable.Executable{Render: &executable.RenderExecutableType{}}
+			Expect(renderRnr.IsCompatible(exec)).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		newExec := func(output executable.RenderExecutableTypeOutput, outputFile string) *executable.Executable {
+			exec := &executable.Executable{
+				Render: &executable.RenderExecutableType{
+					Dir:              executable.Directory("//"),
+					TemplateFile:     "template.md",
+					TemplateDataFile: "data.yaml",
+					Output:           output,
+					OutputFile:       outputFile,
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", "")
+			return exec
+		}
+
+		It("should print to stdout when interactive mode is disabled", func() {
+			out, err := os.CreateTemp(GinkgoT().TempDir(), "stdout")
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(ctx.Ctx.StdIn(), out)
+
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(renderRnr.Exec(ctx.Ctx, newExec(executable.RenderExecutableTypeOutputTui, ""), mockEngine, nil)).
+				To(Succeed())
+			data, err := os.ReadFile(out.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("Report"))
+		})
+
+		It("should write the raw markdown to a file", func() {
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+			exec := newExec(executable.RenderExecutableTypeOutputFile, "out/report.md")
+			Expect(renderRnr.Exec(ctx.Ctx, exec, mockEngine, nil)).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(wsDir, "out", "report.md"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix("# Report\n"))
+		})
+
+		It("should write the content as html", func() {
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+			exec := newExec(executable.RenderExecutableTypeOutputHtml, "report.html")
+			Expect(renderRnr.Exec(ctx.Ctx, exec, mockEngine, nil)).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(wsDir, "report.html"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("<title>template.md</title>"))
+			Expect(string(data)).To(ContainSubstring("<h1>Report</h1>"))
+			Expect(string(data)).To(ContainSubstring("<table>"))
+		})
+	})
+
+	Describe("Exec with renderAs", func() {
+		var out *os.File
+
+		BeforeEach(func() {
+			var err error
+			out, err = os.CreateTemp(GinkgoT().TempDir(), "stdout")
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(ctx.Ctx.StdIn(), out)
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+		})
+
+		renderFile := func(name, content string, format executable.ViewFormat) string {
+			Expect(os.WriteFile(filepath.Join(wsDir, name), []byte(content), 0600)).To(Succeed())
+			exec := &executable.Executable{
+				Render: &executable.RenderExecutableType{
+					Dir:          executable.Directory("//"),
+					TemplateFile: name,
+					RenderAs:     format,
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", "")
+			Expect(renderRnr.Exec(ctx.Ctx, exec, mockEngine, nil)).To(Succeed())
+			data, err := os.ReadFile(out.Name())
+			Expect(err).NotTo(HaveOccurred())
+			return string(data)
+		}
+
+		It("should print yaml data as indented json", func() {
+			output := renderFile("dump.yaml", "name: flow\ntags: [a, b]\n", executable.ViewFormatJson)
+			Expect(output).To(Equal("{\n  \"name\": \"flow\",\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}\n"))
+		})
+
+		It("should print csv data as a table", func() {
+			output := renderFile("report.csv", "name,count\nalpha,10\nbeta,2\n", executable.ViewFormatTable)
+			Expect(output).To(Equal("name   count\nalpha  10\nbeta   2\n"))
+		})
+
+		It("should print a list of objects as a table", func() {
+			output := renderFile("items.json", `[{"id": 1, "name": "a"}, {"id": 2}]`, executable.ViewFormatTable)
+			Expect(output).To(Equal("id  name\n1   a\n2   \n"))
+		})
+	})
+
+	Describe("Exec with data sources", func() {
+		var outFile string
+
+		BeforeEach(func() {
+			outFile = filepath.Join(wsDir, "out.md")
+			Expect(os.WriteFile(filepath.Join(wsDir, "base.yaml"), []byte("app:\n  name: base\n  port: 80\n"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(wsDir, "override.json"), []byte(`{"app": {"port": 8080}}`), 0600)).
+				To(Succeed())
+			runner.RegisterRunner(execRunner.NewRunner())
+		})
+
+		AfterEach(func() {
+			runner.Reset()
+		})
+
+		renderTemplate := func(exec *executable.Executable, tmpl string) string {
+			Expect(os.WriteFile(filepath.Join(wsDir, "data.md"), []byte(tmpl), 0600)).To(Succeed())
+			exec.Render.Dir = executable.Directory("//")
+			exec.Render.TemplateFile = "data.md"
+			exec.Render.Output = executable.RenderExecutableTypeOutputFile
+			exec.Render.OutputFile = outFile
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", filepath.Join(wsDir, "test.flow"))
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+			Expect(renderRnr.Exec(ctx.Ctx, exec, mockEngine, nil)).To(Succeed())
+			data, err := os.ReadFile(outFile)
+			Expect(err).NotTo(HaveOccurred())
+			return string(data)
+		}
+
+		It("should deep-merge data files and inline data", func() {
+			exec := &executable.Executable{
+				Name: "report",
+				Verb: "show",
+				Render: &executable.RenderExecutableType{
+					TemplateDataFiles: []string{"base.yaml", "override.json"},
+					Data:              executable.RenderExecutableTypeData{"app": map[string]interface{}{"env": "dev"}},
+				},
+			}
+			out := renderTemplate(exec, "{{ .data.app.name }} {{ .data.app.port }} {{ .app.env }}")
+			Expect(out).To(Equal("base 8080 dev"))
+		})
+
+		It("should expose the env, store and context", func() {
+			str, err := store.NewStore()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = str.CreateAndSetBucket(store.EnvironmentBucket())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(str.Set("version", "1.2.3")).To(Succeed())
+			Expect(str.Close()).To(Succeed())
+
+			exec := &executable.Executable{
+				Name: "report",
+				Verb: "show",
+				Render: &executable.RenderExecutableType{
+					Params: executable.ParameterList{{EnvKey: "GREETING", Text: "hi"}},
+				},
+			}
+			out := renderTemplate(exec, "{{ .env.GREETING }} {{ .store.version }} {{ .ctx.workspace }}")
+			Expect(out).To(Equal("hi 1.2.3 " + ctx.Ctx.CurrentWorkspace.AssignedName()))
+		})
+
+		It("should warn when a data key is shadowed by a built-in key", func() {
+			exec := &executable.Executable{
+				Name: "report",
+				Verb: "show",
+				Render: &executable.RenderExecutableType{
+					Data: executable.RenderExecutableTypeData{"env": "prod"},
+				},
+			}
+			ctx.Logger.EXPECT().Warnf(gomock.Any(), "env").Times(1)
+			out := renderTemplate(exec, "{{ .data.env }}")
+			Expect(out).To(Equal("prod"))
+		})
+
+		It("should add the output of another executable", func() {
+			source := &executable.Executable{
+				Name: "count",
+				Verb: "get",
+				Exec: &executable.ExecExecutableType{Cmd: `echo '{"count": 3}'`},
+			}
+			source.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", filepath.Join(wsDir, "test.flow"))
+			ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).Return(source, nil)
+
+			exec := &executable.Executable{
+				Name: "report",
+				Verb: "show",
+				Render: &executable.RenderExecutableType{
+					DataFrom: []executable.RenderDataSource{{Key: "stats", Ref: source.Ref()}},
+				},
+			}
+			out := renderTemplate(exec, "count={{ .data.stats.count }}")
+			Expect(out).To(Equal("count=3"))
+		})
+
+		It("should add the response body of a request executable", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"status": "healthy"}`))
+			}))
+			defer server.Close()
+			source := &executable.Executable{
+				Name:    "health",
+				Verb:    "get",
+				Request: &executable.RequestExecutableType{URL: server.URL},
+			}
+			source.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", filepath.Join(wsDir, "test.flow"))
+			ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).Return(source, nil)
+
+			exec := &executable.Executable{
+				Name: "report",
+				Verb: "show",
+				Render: &executable.RenderExecutableType{
+					DataFrom: []executable.RenderDataSource{{Key: "health", Ref: source.Ref()}},
+				},
+			}
+			out := renderTemplate(exec, "{{ .data.health.status }}")
+			Expect(out).To(Equal("healthy"))
+		})
+	})
+
+	Describe("Exec in watch mode", func() {
+		It("should re-render the content when the template changes", func() {
+			watchCtx, cancel := stdCtx.WithCancel(stdCtx.Background())
+			ctx.Ctx.Ctx = watchCtx
+			outFile := filepath.Join(wsDir, "watched.md")
+			exec := &executable.Executable{
+				Render: &executable.RenderExecutableType{
+					Dir:              executable.Directory("//"),
+					TemplateFile:     "template.md",
+					TemplateDataFile: "data.yaml",
+					Output:           executable.RenderExecutableTypeOutputFile,
+					OutputFile:       outFile,
+					Watch:            true,
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", "")
+			readOutput := func() string {
+				data, _ := os.ReadFile(outFile)
+				return string(data)
+			}
+
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			ctx.Logger.EXPECT().Errorf(gomock.Any(), gomock.Any()).MinTimes(1)
+			done := make(chan error)
+			go func() {
+				done <- renderRnr.Exec(ctx.Ctx, exec, mockEngine, nil)
+			}()
+			Eventually(readOutput).Should(HavePrefix("# Report\n"))
+
+			Expect(os.WriteFile(filepath.Join(wsDir, "data.yaml"), []byte("title: Updated\n"), 0600)).To(Succeed())
+			Eventually(readOutput).Should(HavePrefix("# Updated\n"))
+
+			// The last rendered content is kept when the template is invalid.
+			Expect(os.WriteFile(filepath.Join(wsDir, "template.md"), []byte("{{ .title "), 0600)).To(Succeed())
+			Consistently(readOutput, "1s").Should(HavePrefix("# Updated\n"))
+
+			Expect(os.WriteFile(filepath.Join(wsDir, "template.md"), []byte("## {{ .title }}"), 0600)).To(Succeed())
+			Eventually(readOutput).Should(Equal("## Updated"))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
+})
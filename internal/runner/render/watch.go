@@ -0,0 +1,114 @@
+package render
+
+import (
+	stdCtx "context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jahvon/tuikit/styles"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	watchInterval = 500 * time.Millisecond
+	clearScreen   = "\033[H\033[2J"
+)
+
+func watchedFiles(dir, templateFile string, spec *executable.RenderExecutableType) []string {
+	files := []string{templateFile}
+	if spec.TemplateDataFile != "" {
+		files = append(files, filepath.Clean(filepath.Join(dir, spec.TemplateDataFile)))
+	}
+	for _, file := range spec.TemplateDataFiles {
+		files = append(files, filepath.Clean(filepath.Join(dir, file)))
+	}
+	return files
+}
+
+// watchContent renders the content and re-renders it each time one of the files changes. Errors are shown in place
+// of the content so that they can be fixed without restarting. The content is watched until the TUI is exited or
+// the context is cancelled.
+func watchContent(
+	ctx *context.Context,
+	out *contentWriter,
+	files []string,
+	renderContent func() (string, error),
+) error {
+	// Exiting the view only stops the watch, so that a render that is run as a step of a serial or parallel
+	// executable doesn't cancel the rest of the flow.
+	watchCtx, cancel := stdCtx.WithCancel(ctx.Ctx)
+	defer cancel()
+	done := watchCtx.Done()
+	if out.output == executable.RenderExecutableTypeOutputTui {
+		go func() {
+			ctx.TUIContainer.WaitForExit()
+			cancel()
+		}()
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	modTimes := fileModTimes(files)
+	for reloads := 0; ; reloads++ {
+		if out.output == executable.RenderExecutableTypeOutputStdout {
+			_, _ = fmt.Fprint(ctx.StdOut(), clearScreen)
+		}
+		content, err := renderContent()
+		switch {
+		case err != nil:
+			if err := out.writeError(err); err != nil {
+				return err
+			}
+		case reloads > 0 && out.output == executable.RenderExecutableTypeOutputTui:
+			ctx.TUIContainer.SetNotice("reloaded "+out.filename, styles.OutputLevelSuccess)
+			fallthrough
+		default:
+			if err := out.write(content); err != nil {
+				return err
+			}
+		}
+
+		for changed := false; !changed; {
+			select {
+			case <-done:
+				return nil
+			case <-ticker.C:
+				current := fileModTimes(files)
+				changed = !maps.Equal(current, modTimes)
+				modTimes = current
+			}
+		}
+		ctx.Logger.Debugf("template files changed, re-rendering %s", out.filename)
+	}
+}
+
+// writeError displays the render error in place of the content. The error is logged for file outputs so that the
+// previously written file is kept.
+func (w *contentWriter) writeError(err error) error {
+	switch w.output {
+	case executable.RenderExecutableTypeOutputFile, executable.RenderExecutableTypeOutputHtml:
+		w.ctx.Logger.Errorf("unable to render %s: %v", w.filename, err)
+		return nil
+	case executable.RenderExecutableTypeOutputTui:
+		w.ctx.TUIContainer.SetNotice("template error", styles.OutputLevelError)
+	}
+	return w.writeMarkdown(fmt.Sprintf("# Template Error\n\n```\n%s\n```\n", err))
+}
+
+// fileModTimes returns the modification time of each file. Missing files have a zero time.
+func fileModTimes(files []string) map[string]time.Time {
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		} else {
+			modTimes[file] = time.Time{}
+		}
+	}
+	return modTimes
+}
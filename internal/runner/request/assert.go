@@ -0,0 +1,150 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/expr"
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/internal/services/store"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// responseEnv returns the data that assert and extract expressions are evaluated against. The duration is in
+// milliseconds so that it does not shadow the Expr duration builtin.
+func responseEnv(resp *rest.Response, duration time.Duration, envMap map[string]string) map[string]interface{} {
+	headers := make(map[string]string, len(resp.Headers))
+	for key := range resp.Headers {
+		headers[key] = resp.Headers.Get(key)
+	}
+	return map[string]interface{}{
+		"status":   resp.StatusCode,
+		"headers":  headers,
+		"body":     parseBody(resp.Body),
+		"duration": duration.Milliseconds(),
+		"env":      envMap,
+	}
+}
+
+// parseBody returns the body parsed as JSON or the raw body if it is not valid JSON.
+func parseBody(body string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+		return body
+	}
+	return parsed
+}
+
+// checkAssertions evaluates each assertion and returns an error listing every assertion that failed along with
+// the actual values of the variables it references. The pipe operator has the lowest precedence in Expr, so
+// `body.items | len > 0` does not compile; assertions must use `len(body.items) > 0` or `(body.items | len()) > 0`.
+func checkAssertions(assertions []string, env map[string]interface{}) error {
+	var failures []string
+	for _, assertion := range assertions {
+		ok, err := expr.IsTruthy(assertion, env)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("- %s\n  error: %v", assertion, err))
+		case !ok:
+			failure := "- " + assertion
+			if actual := actualValues(assertion, env); actual != "" {
+				failure += "\n  actual: " + actual
+			}
+			failures = append(failures, failure)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d assertions failed:\n%s", len(failures), len(assertions), strings.Join(failures, "\n"))
+}
+
+func actualValues(assertion string, env map[string]interface{}) string {
+	vars, err := expr.Variables(assertion)
+	if err != nil {
+		return ""
+	}
+	values := make([]string, 0, len(vars))
+	for _, v := range vars {
+		val, err := expr.Evaluate(v, env)
+		if err != nil {
+			continue
+		}
+		values = append(values, fmt.Sprintf("%s = %s", v, formatValue(val)))
+	}
+	return strings.Join(values, ", ")
+}
+
+// extractValues evaluates each extract rule and saves the results to the process store.
+func extractValues(
+	logger io.Logger,
+	rules []executable.RequestExtract,
+	env map[string]interface{},
+) error {
+	values := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		var val interface{}
+		var err error
+		if rule.Jq != "" {
+			val, err = evaluateJQ(rule.Jq, env["body"])
+		} else {
+			val, err = expr.Evaluate(rule.Expr, env)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to extract %s", rule.Key)
+		}
+		values[rule.Key] = formatValue(val)
+	}
+
+	str, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer str.Close()
+	if _, err := str.CreateAndSetBucket(store.EnvironmentBucket()); err != nil {
+		return err
+	}
+	for key, val := range values {
+		if err := str.Set(key, val); err != nil {
+			return errors.Wrapf(err, "unable to save %s", key)
+		}
+		logger.Debugf("extracted response value to %s", key)
+	}
+	return nil
+}
+
+func evaluateJQ(query string, body interface{}) (interface{}, error) {
+	if _, isStr := body.(string); isStr {
+		return nil, errors.New("response is not a valid JSON string")
+	}
+	jqQuery, err := gojq.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := jqQuery.Run(body).Next()
+	if !ok {
+		return nil, errors.New("unable to execute jq query")
+	}
+	if err, isErr := result.(error); isErr {
+		return nil, err
+	}
+	return result, nil
+}
+
+// formatValue returns strings as-is and all other values as JSON.
+func formatValue(val interface{}) string {
+	if str, ok := val.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(data)
+}
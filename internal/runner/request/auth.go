@@ -0,0 +1,203 @@
+package request
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/internal/services/store"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	oauth2StoreKeyPrefix = "flow.oauth2."
+	// oauth2ExpirySkew is subtracted from the token lifetime so that a cached token is not used
+	// right before it expires.
+	oauth2ExpirySkew = 30 * time.Second
+)
+
+type oauth2Token struct {
+	AccessToken string    `json:"accessToken"`
+	ExpiresAt   time.Time `json:"expiresAt,omitempty"`
+}
+
+func (t *oauth2Token) expired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt)
+}
+
+// applyAuth sets the credentials defined by the auth spec on the request. If refresh is true, any cached
+// OAuth2 token is discarded and a new one is requested. The token is requested with the request's client config.
+func applyAuth(
+	logger io.Logger,
+	auth *executable.RequestAuth,
+	envMap map[string]string,
+	req *rest.Request,
+	refresh bool,
+) error {
+	if auth == nil {
+		return nil
+	}
+	if req.Headers == nil {
+		req.Headers = make(map[string]string)
+	}
+
+	switch {
+	case auth.Basic != nil:
+		password, err := runner.ResolveSecretValue(logger, auth.Basic.SecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve basic auth password")
+		}
+		username := expandEnvVars(envMap, auth.Basic.Username)
+		creds := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		req.Headers["Authorization"] = "Basic " + creds
+	case auth.Bearer != nil:
+		token, err := resolveAuthValue(logger, envMap, auth.Bearer.Token, auth.Bearer.SecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve bearer token")
+		}
+		req.Headers["Authorization"] = "Bearer " + token
+	case auth.ApiKey != nil:
+		key, err := resolveAuthValue(logger, envMap, auth.ApiKey.Value, auth.ApiKey.SecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve api key")
+		}
+		if auth.ApiKey.In != executable.RequestAPIKeyAuthInQuery {
+			req.Headers[auth.ApiKey.Name] = key
+			break
+		}
+		if req.Query == nil {
+			req.Query = make(map[string]string)
+		}
+		req.Query[auth.ApiKey.Name] = key
+	case auth.Oauth2ClientCredentials != nil:
+		token, err := oauth2AccessToken(logger, auth.Oauth2ClientCredentials, envMap, req.Timeout, req.Client, refresh)
+		if err != nil {
+			return errors.Wrap(err, "unable to retrieve oauth2 access token")
+		}
+		req.Headers["Authorization"] = "Bearer " + token
+	}
+	return nil
+}
+
+func resolveAuthValue(logger io.Logger, envMap map[string]string, value, secretRef string) (string, error) {
+	if secretRef != "" {
+		return runner.ResolveSecretValue(logger, secretRef)
+	}
+	return expandEnvVars(envMap, value), nil
+}
+
+func oauth2AccessToken(
+	logger io.Logger,
+	spec *executable.RequestOAuth2ClientCredentials,
+	envMap map[string]string,
+	timeout time.Duration,
+	client *rest.ClientConfig,
+	refresh bool,
+) (string, error) {
+	clientID := expandEnvVars(envMap, spec.ClientID)
+	tokenURL := expandEnvVars(envMap, spec.TokenURL)
+	cacheKey := oauth2CacheKey(tokenURL, clientID, spec.Scopes)
+
+	str, err := store.NewStore()
+	if err != nil {
+		return "", err
+	}
+	defer str.Close()
+	if _, err := str.CreateAndSetBucket(store.RootBucket); err != nil {
+		return "", err
+	}
+
+	if !refresh {
+		if cached, err := str.Get(cacheKey); err == nil && cached != "" {
+			var token oauth2Token
+			if err := json.Unmarshal([]byte(cached), &token); err == nil && !token.expired() {
+				logger.Debugf("using cached oauth2 token for client %s", clientID)
+				return token.AccessToken, nil
+			}
+		}
+	}
+
+	clientSecret, err := runner.ResolveSecretValue(logger, spec.ClientSecretRef)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to resolve client secret")
+	}
+	token, err := requestOAuth2Token(tokenURL, clientID, clientSecret, spec.Scopes, timeout, client)
+	if err != nil {
+		return "", err
+	}
+
+	tokenData, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	if err := str.Set(cacheKey, string(tokenData)); err != nil {
+		logger.Warnx("unable to cache oauth2 token", "err", err)
+	}
+	return token.AccessToken, nil
+}
+
+func requestOAuth2Token(
+	tokenURL, clientID, clientSecret string,
+	scopes []string,
+	timeout time.Duration,
+	client *rest.ClientConfig,
+) (*oauth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	resp, err := rest.Send(&rest.Request{
+		URL:    tokenURL,
+		Method: http.MethodPost,
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+			"Accept":       "application/json",
+		},
+		Body:    form.Encode(),
+		Timeout: timeout,
+		Client:  client,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !rest.IsStatusCodeAccepted(resp.StatusCode, nil) {
+		return nil, fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &tokenResp); err != nil {
+		return nil, errors.Wrap(err, "unable to parse token response")
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New("token response did not include an access_token")
+	}
+
+	token := &oauth2Token{AccessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn > 0 {
+		lifetime := time.Duration(tokenResp.ExpiresIn) * time.Second
+		token.ExpiresAt = time.Now().Add(lifetime - min(oauth2ExpirySkew, lifetime/2))
+	}
+	return token, nil
+}
+
+func oauth2CacheKey(tokenURL, clientID string, scopes []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{tokenURL, clientID}, scopes...), "|")))
+	return oauth2StoreKeyPrefix + hex.EncodeToString(sum[:8])
+}
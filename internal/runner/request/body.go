@@ -0,0 +1,220 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/internal/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	contentTypeHeader = "Content-Type"
+	contentTypeJSON   = "application/json"
+	contentTypeForm   = "application/x-www-form-urlencoded"
+	contentTypeBinary = "application/octet-stream"
+)
+
+// setRequestBody sets the body of the request from the first body field that is defined in the request spec.
+// Environment variables are expanded in all values and file paths. The Content-Type header is set based on
+// the body type unless it was already set.
+func setRequestBody(
+	logger io.Logger,
+	e *executable.Executable,
+	envMap map[string]string,
+	req *rest.Request,
+) error {
+	spec := e.Request
+	var contentType string
+	switch {
+	case spec.GraphQL != nil:
+		var err error
+		if contentType, err = setGraphQLRequest(logger, e, envMap, req); err != nil {
+			return err
+		}
+	case len(spec.Form) > 0:
+		form := url.Values{}
+		for k, v := range spec.Form {
+			form.Set(expandEnvVars(envMap, k), expandEnvVars(envMap, v))
+		}
+		req.Body = form.Encode()
+		contentType = contentTypeForm
+	case len(spec.Multipart) > 0:
+		parts := make([]executable.RequestMultipartPart, 0, len(spec.Multipart))
+		for _, part := range spec.Multipart {
+			p := executable.RequestMultipartPart{
+				Name:        expandEnvVars(envMap, part.Name),
+				Value:       expandEnvVars(envMap, part.Value),
+				Filename:    expandEnvVars(envMap, part.Filename),
+				ContentType: expandEnvVars(envMap, part.ContentType),
+			}
+			if part.File != "" {
+				p.File = resolvePath(logger, e, envMap, part.File)
+			}
+			parts = append(parts, p)
+		}
+		boundary := multipart.NewWriter(stdio.Discard).Boundary()
+		req.BodyReader = func() (stdio.ReadCloser, error) {
+			return multipartReader(parts, boundary), nil
+		}
+		contentType = "multipart/form-data; boundary=" + boundary
+	case spec.BodyFile != "":
+		path := resolvePath(logger, e, envMap, spec.BodyFile)
+		if _, err := os.Stat(path); err != nil {
+			return errors.Wrap(err, "unable to read body file")
+		}
+		req.BodyReader = func() (stdio.ReadCloser, error) {
+			return os.Open(filepath.Clean(path))
+		}
+		contentType = contentTypeForFile(path)
+	case spec.JSON != nil:
+		data, err := json.Marshal(expandEnvVarsInValue(envMap, spec.JSON))
+		if err != nil {
+			return errors.Wrap(err, "unable to marshal json body")
+		}
+		req.Body = string(data)
+		contentType = contentTypeJSON
+	default:
+		req.Body = expandEnvVars(envMap, spec.Body)
+	}
+
+	if contentType != "" && !hasHeader(req.Headers, contentTypeHeader) {
+		if req.Headers == nil {
+			req.Headers = make(map[string]string)
+		}
+		req.Headers[contentTypeHeader] = contentType
+	}
+	return nil
+}
+
+// multipartReader streams the multipart body so that files are not loaded into memory.
+func multipartReader(parts []executable.RequestMultipartPart, boundary string) stdio.ReadCloser {
+	pr, pw := stdio.Pipe()
+	go func() {
+		writer := multipart.NewWriter(pw)
+		if err := writer.SetBoundary(boundary); err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		for _, part := range parts {
+			if err := writeMultipartPart(writer, part); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+		_ = pw.CloseWithError(writer.Close())
+	}()
+	return pr
+}
+
+func writeMultipartPart(writer *multipart.Writer, part executable.RequestMultipartPart) error {
+	if part.File == "" {
+		if part.ContentType == "" {
+			return writer.WriteField(part.Name, part.Value)
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(part.Name)))
+		header.Set(contentTypeHeader, part.ContentType)
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = stdio.WriteString(w, part.Value)
+		return err
+	}
+
+	file, err := os.Open(filepath.Clean(part.File))
+	if err != nil {
+		return errors.Wrapf(err, "unable to open multipart file for field %s", part.Name)
+	}
+	defer file.Close()
+
+	filename := part.Filename
+	if filename == "" {
+		filename = filepath.Base(part.File)
+	}
+	contentType := part.ContentType
+	if contentType == "" {
+		contentType = contentTypeForFile(part.File)
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set(
+		"Content-Disposition",
+		fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(part.Name), escapeQuotes(filename)),
+	)
+	header.Set(contentTypeHeader, contentType)
+	w, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = stdio.Copy(w, file)
+	return err
+}
+
+// resolvePath expands the path relative to the executable's directory. The same expansion rules as
+// the executable's `dir` field are applied.
+func resolvePath(logger io.Logger, e *executable.Executable, envMap map[string]string, path string) string {
+	return utils.ExpandDirectory(logger, path, e.WorkspacePath(), e.FlowFilePath(), envMap)
+}
+
+// expandEnvVarsInValue expands environment variables in every string of a structured value.
+func expandEnvVarsInValue(envMap map[string]string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return expandEnvVars(envMap, v)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			expanded[expandEnvVars(envMap, k)] = expandEnvVarsInValue(envMap, val)
+		}
+		return expanded
+	case map[interface{}]interface{}:
+		expanded := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			expanded[expandEnvVars(envMap, fmt.Sprintf("%v", k))] = expandEnvVarsInValue(envMap, val)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			expanded = append(expanded, expandEnvVarsInValue(envMap, val))
+		}
+		return expanded
+	default:
+		return v
+	}
+}
+
+func contentTypeForFile(path string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(path)); ct != "" {
+		return ct
+	}
+	return contentTypeBinary
+}
+
+func hasHeader(headers map[string]string, key string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
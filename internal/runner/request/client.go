@@ -0,0 +1,57 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// clientConfig merges the user's global HTTP client config with the executable's config and resolves
+// its file paths and secrets. Fields set on the executable take precedence.
+func clientConfig(
+	ctx *context.Context,
+	e *executable.Executable,
+	envMap map[string]string,
+) (*rest.ClientConfig, error) {
+	cfg := ctx.Config.HTTPClientConfig().Merge(e.Request.HTTPClientConfig())
+	if cfg == nil {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	logger := ctx.Logger
+	clientCfg := &rest.ClientConfig{
+		Proxy:              expandEnvVars(envMap, cfg.Proxy),
+		InsecureSkipVerify: cfg.InsecureSkipVerify != nil && *cfg.InsecureSkipVerify,
+		DisableRedirects:   cfg.FollowRedirects != nil && !*cfg.FollowRedirects,
+		DisableHTTP2:       cfg.Http2 != nil && !*cfg.Http2,
+	}
+	if cfg.MaxRedirects != nil {
+		clientCfg.MaxRedirects = *cfg.MaxRedirects
+	}
+	if cfg.CaFile != "" {
+		clientCfg.CAFile = resolvePath(logger, e, envMap, cfg.CaFile)
+	}
+	if cfg.CertFile != "" {
+		clientCfg.CertFile = resolvePath(logger, e, envMap, cfg.CertFile)
+		clientCfg.KeyFile = resolvePath(logger, e, envMap, cfg.KeyFile)
+	}
+	if cfg.CertSecretRef != "" {
+		cert, err := runner.ResolveSecretValue(logger, cfg.CertSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to resolve client certificate")
+		}
+		key, err := runner.ResolveSecretValue(logger, cfg.KeySecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to resolve client key")
+		}
+		clientCfg.CertPEM = []byte(cert)
+		clientCfg.KeyPEM = []byte(key)
+	}
+	return clientCfg, nil
+}
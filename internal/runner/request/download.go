@@ -0,0 +1,239 @@
+package request
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	rangeHeader        = "Range"
+	partialFileSuffix  = ".part"
+	progressBarWidth   = 40
+	barRefreshInterval = 100 * time.Millisecond
+	logReportInterval  = 5 * time.Second
+)
+
+// streamable returns true if the response body is only saved to a file and can be streamed to it.
+func streamable(spec *executable.RequestExecutableType) bool {
+	saveAs := spec.ResponseFile.SaveAs
+	return (saveAs == "" || saveAs == executable.RequestResponseFileSaveAsRaw) &&
+		!spec.LogResponse &&
+		spec.ViewResponse == "" &&
+		spec.TransformResponse == "" &&
+		spec.GraphQL == nil &&
+		spec.Paginate == nil &&
+		len(spec.Assert) == 0 &&
+		len(spec.Extract) == 0
+}
+
+// downloadResponse streams the response body to the file. The body is written to a partial file that is
+// renamed once the download completes. If a partial file exists from a previous attempt, the download is
+// resumed with a Range request.
+func downloadResponse(
+	ctx *context.Context,
+	req *rest.Request,
+	spec *executable.RequestExecutableType,
+	envMap map[string]string,
+	path string,
+) error {
+	logger := ctx.Logger
+	req.StreamBody = true
+	partPath := path + partialFileSuffix
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil && info.Size() > 0 {
+		offset = info.Size()
+		req.Headers[rangeHeader] = fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	resp, err := openResponse(logger, req, spec, envMap)
+	var statusErr *rest.StatusError
+	if offset > 0 && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		logger.Debugf("unable to resume download of %s, restarting", path)
+		delete(req.Headers, rangeHeader)
+		offset = 0
+		resp, err = openResponse(logger, req, spec, envMap)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent && contentRangeStart(resp.Header) == offset {
+		logger.Debugf("resuming download of %s from byte %d", path, offset)
+		flags |= os.O_APPEND
+	} else {
+		offset = 0
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(filepath.Clean(partPath), flags, 0644) //nolint:gosec
+	if err != nil {
+		return errors.Wrap(err, "unable to open response file")
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	reporter := newProgressReporter(ctx, filepath.Base(path), offset, total)
+	_, err = stdio.Copy(stdio.MultiWriter(file, reporter), resp.Body)
+	reporter.finish()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(
+			err, "download interrupted after %s; it will be resumed on the next run", formatBytes(reporter.written),
+		)
+	}
+
+	if err := verifyChecksum(partPath, spec.ResponseFile.Sha256); err != nil {
+		return err
+	}
+	return os.Rename(partPath, path)
+}
+
+// verifyChecksum compares the SHA-256 checksum of the file with the expected hex checksum. The file is
+// removed if the checksums do not match.
+func verifyChecksum(path, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	_, err = stdio.Copy(hash, file)
+	_ = file.Close()
+	if err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		_ = os.Remove(path)
+		return fmt.Errorf("sha256 checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+func contentRangeStart(headers http.Header) int64 {
+	// Content-Range: bytes <start>-<end>/<size>
+	value := strings.TrimPrefix(headers.Get("Content-Range"), "bytes ")
+	start, _, found := strings.Cut(value, "-")
+	if !found {
+		return -1
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// progressReporter reports the progress of a download as it is written. A progress bar is drawn when the
+// interactive UI is enabled and the output is a terminal, otherwise the progress is logged periodically.
+type progressReporter struct {
+	logger   io.Logger
+	name     string
+	out      *os.File
+	bar      *progress.Model
+	written  int64
+	total    int64
+	reported time.Time
+}
+
+func newProgressReporter(ctx *context.Context, name string, offset, total int64) *progressReporter {
+	r := &progressReporter{
+		logger:   ctx.Logger,
+		name:     name,
+		written:  offset,
+		total:    total,
+		reported: time.Now(),
+	}
+	if ctx.ShowTUI() && isTerminal(ctx.StdOut()) {
+		bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(progressBarWidth))
+		r.bar = &bar
+		r.out = ctx.StdOut()
+	}
+	return r
+}
+
+func (r *progressReporter) Write(p []byte) (int, error) {
+	r.written += int64(len(p))
+	interval := logReportInterval
+	if r.bar != nil {
+		interval = barRefreshInterval
+	}
+	if time.Since(r.reported) >= interval {
+		r.report()
+		r.reported = time.Now()
+	}
+	return len(p), nil
+}
+
+func (r *progressReporter) report() {
+	size := formatBytes(r.written)
+	if r.total > 0 {
+		size = fmt.Sprintf("%s / %s", size, formatBytes(r.total))
+	}
+	if r.bar == nil {
+		if r.total > 0 {
+			r.logger.Infof("Downloading %s: %s (%d%%)", r.name, size, r.written*100/r.total)
+		} else {
+			r.logger.Infof("Downloading %s: %s", r.name, size)
+		}
+		return
+	}
+	if r.total > 0 {
+		_, _ = fmt.Fprintf(r.out, "\r%s %s %s", r.name, r.bar.ViewAs(float64(r.written)/float64(r.total)), size)
+	} else {
+		_, _ = fmt.Fprintf(r.out, "\r%s %s", r.name, size)
+	}
+}
+
+func (r *progressReporter) finish() {
+	if r.bar == nil {
+		return
+	}
+	r.report()
+	_, _ = fmt.Fprintln(r.out)
+}
+
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
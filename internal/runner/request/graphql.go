@@ -0,0 +1,109 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type graphQLRequest struct {
+	Query         string      `json:"query"`
+	Variables     interface{} `json:"variables,omitempty"`
+	OperationName string      `json:"operationName,omitempty"`
+}
+
+type graphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// setGraphQLRequest sets the GraphQL operation as the request body. If the request method is GET, the
+// operation is sent as query parameters instead.
+func setGraphQLRequest(
+	logger io.Logger,
+	e *executable.Executable,
+	envMap map[string]string,
+	req *rest.Request,
+) (string, error) {
+	spec := e.Request.GraphQL
+	query := spec.Query
+	if spec.QueryFile != "" {
+		path := resolvePath(logger, e, envMap, spec.QueryFile)
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to read graphql query file")
+		}
+		query = string(data)
+	}
+	operation := graphQLRequest{
+		Query:         query,
+		OperationName: expandEnvVars(envMap, spec.OperationName),
+	}
+	if len(spec.Variables) > 0 {
+		operation.Variables = expandEnvVarsInValue(envMap, map[string]interface{}(spec.Variables))
+	}
+
+	if strings.EqualFold(req.Method, http.MethodGet) {
+		if req.Query == nil {
+			req.Query = make(map[string]string)
+		}
+		req.Query["query"] = operation.Query
+		if operation.Variables != nil {
+			vars, err := json.Marshal(operation.Variables)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to marshal graphql variables")
+			}
+			req.Query["variables"] = string(vars)
+		}
+		if operation.OperationName != "" {
+			req.Query["operationName"] = operation.OperationName
+		}
+		return "", nil
+	}
+
+	if req.Method == "" {
+		req.Method = http.MethodPost
+	}
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to marshal graphql request")
+	}
+	req.Body = string(data)
+	return contentTypeJSON, nil
+}
+
+// checkGraphQLErrors returns an error listing the messages of the `errors` in the GraphQL response.
+func checkGraphQLErrors(body string) error {
+	var resp struct {
+		Errors []graphQLError `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return errors.Wrap(err, "graphql response is not valid JSON")
+	}
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(resp.Errors))
+	for _, gqlErr := range resp.Errors {
+		msg := "- " + gqlErr.Message
+		if len(gqlErr.Path) > 0 {
+			path := make([]string, 0, len(gqlErr.Path))
+			for _, p := range gqlErr.Path {
+				path = append(path, fmt.Sprintf("%v", p))
+			}
+			msg += fmt.Sprintf(" (path: %s)", strings.Join(path, "."))
+		}
+		msgs = append(msgs, msg)
+	}
+	return fmt.Errorf("graphql response contains errors:\n%s", strings.Join(msgs, "\n"))
+}
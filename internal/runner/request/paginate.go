@@ -0,0 +1,173 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jahvon/tuikit/io"
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/rest"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	defaultMaxPages    = 100
+	defaultCursorParam = "cursor"
+	defaultPageParam   = "page"
+)
+
+// sendPaginatedRequest follows the pages of the response and returns the last response with its body replaced by
+// a JSON array of the items from every page.
+func sendPaginatedRequest(
+	logger io.Logger,
+	req *rest.Request,
+	requestSpec *executable.RequestExecutableType,
+	envMap map[string]string,
+) (*rest.Response, error) {
+	paginate := requestSpec.Paginate
+	maxPages := paginate.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	pageNum := paginate.StartPage
+	if pageNum == 0 {
+		pageNum = 1
+	}
+	if req.Query == nil {
+		req.Query = make(map[string]string)
+	}
+
+	items := make([]interface{}, 0)
+	var resp *rest.Response
+	for pageCount := 1; ; pageCount++ {
+		if paginate.Strategy == executable.RequestPaginateStrategyPage {
+			req.Query[paramOrDefault(paginate.PageParam, defaultPageParam)] = strconv.Itoa(pageNum)
+		}
+		var err error
+		resp, err = sendRequest(logger, req, requestSpec, envMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "page %d", pageCount)
+		}
+		body := parseBody(resp.Body)
+		pageItems, err := responseItems(paginate.Items, body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read items from page %d", pageCount)
+		}
+		items = append(items, pageItems...)
+		if pageCount >= maxPages {
+			logger.Debugf("stopped pagination after %d pages", maxPages)
+			break
+		}
+
+		var done bool
+		switch paginate.Strategy {
+		case executable.RequestPaginateStrategyLinkHeader:
+			next := nextLink(resp.Headers)
+			if next == "" {
+				done = true
+				break
+			}
+			nextURL, err := resolveURL(req.URL, next)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid next page link")
+			}
+			// The next link includes the query parameters of the next page.
+			req.URL = nextURL
+			req.Query = make(map[string]string)
+		case executable.RequestPaginateStrategyCursor:
+			cursor, err := evaluateJQ(paginate.Cursor, body)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to read cursor from page %d", pageCount)
+			}
+			if cursor == nil || cursor == "" {
+				done = true
+				break
+			}
+			req.Query[paramOrDefault(paginate.CursorParam, defaultCursorParam)] = formatValue(cursor)
+		case executable.RequestPaginateStrategyPage:
+			done = len(pageItems) == 0
+			pageNum++
+		}
+		if done {
+			break
+		}
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return &rest.Response{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    resp.Headers,
+		Body:       string(data),
+	}, nil
+}
+
+func responseItems(query string, body interface{}) ([]interface{}, error) {
+	result := body
+	if query != "" {
+		var err error
+		if result, err = evaluateJQ(query, body); err != nil {
+			return nil, err
+		}
+	}
+	switch items := result.(type) {
+	case []interface{}:
+		return items, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("expected a list of items, got %T", result)
+	}
+}
+
+// nextLink returns the URL of the `next` relation in the Link header.
+func nextLink(headers http.Header) string {
+	for _, header := range headers.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			segments := strings.Split(link, ";")
+			if len(segments) < 2 {
+				continue
+			}
+			target := strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			for _, param := range segments[1:] {
+				key, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(val, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func resolveURL(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+func paramOrDefault(param, defaultParam string) string {
+	if param == "" {
+		return defaultParam
+	}
+	return param
+}
@@ -3,10 +3,13 @@ package request
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/itchyny/gojq"
+	"github.com/jahvon/tuikit/io"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -46,37 +49,12 @@ func (r *requestRunner) Exec(
 		return errors.Wrap(err, "unable to set parameters to env")
 	}
 
-	url := expandEnvVars(envMap, requestSpec.URL)
-	body := expandEnvVars(envMap, requestSpec.Body)
-	for key, value := range requestSpec.Headers {
-		requestSpec.Headers[key] = expandEnvVars(envMap, value)
-	}
-	restRequest := rest.Request{
-		URL:     url,
-		Method:  string(requestSpec.Method),
-		Headers: requestSpec.Headers,
-		Body:    body,
-		Timeout: requestSpec.Timeout,
-	}
-	resp, err := rest.SendRequest(&restRequest, requestSpec.ValidStatusCodes)
+	restRequest, err := newRequest(ctx, e, envMap)
 	if err != nil {
-		return errors.Wrap(err, "request failed")
-	}
-
-	if requestSpec.TransformResponse != "" {
-		resp, err = executeJQQuery(requestSpec.TransformResponse, resp)
-		if err != nil {
-			return errors.Wrap(err, "unable to transform response")
-		}
-	}
-
-	logger := ctx.Logger
-	if requestSpec.LogResponse {
-		logger.Infox(fmt.Sprintf("Successfully sent request to %s", requestSpec.URL), "response", resp)
-	} else {
-		logger.Infof("Successfully sent request to %s", requestSpec.URL)
+		return err
 	}
 
+	var responseFile string
 	if requestSpec.ResponseFile != nil && requestSpec.ResponseFile.Filename != "" {
 		targetDir, isTmp, err := requestSpec.ResponseFile.Dir.ExpandDirectory(
 			ctx.Logger,
@@ -90,12 +68,35 @@ func (r *requestRunner) Exec(
 		} else if isTmp {
 			ctx.ProcessTmpDir = targetDir
 		}
+		responseFile = filepath.Join(targetDir, requestSpec.ResponseFile.Filename)
+	}
 
-		err = writeResponseToFile(
-			resp,
-			filepath.Join(targetDir, requestSpec.ResponseFile.Filename),
-			requestSpec.ResponseFile.SaveAs,
-		)
+	logger := ctx.Logger
+	if responseFile != "" && streamable(requestSpec) {
+		if err := downloadResponse(ctx, &restRequest, requestSpec, envMap, responseFile); err != nil {
+			return errors.Wrap(err, "download failed")
+		}
+		logger.Infof("Successfully sent request to %s", requestSpec.URL)
+		logger.Infof("Successfully saved response to %s", requestSpec.ResponseFile.Filename)
+		return nil
+	}
+
+	resp, err := fetchResponse(ctx.Logger, &restRequest, requestSpec, envMap)
+	if err != nil {
+		return err
+	}
+
+	if requestSpec.LogResponse {
+		logger.Infox(fmt.Sprintf("Successfully sent request to %s", requestSpec.URL), "response", resp)
+	} else {
+		logger.Infof("Successfully sent request to %s", requestSpec.URL)
+	}
+
+	if responseFile != "" {
+		err = writeResponseToFile(resp, responseFile, requestSpec.ResponseFile.SaveAs)
+		if err == nil {
+			err = verifyChecksum(responseFile, requestSpec.ResponseFile.Sha256)
+		}
 		if err != nil {
 			return errors.Wrap(err, "unable to save response")
 		} else {
@@ -103,11 +104,176 @@ func (r *requestRunner) Exec(
 		}
 	}
 
+	if requestSpec.ViewResponse != "" {
+		return viewResponse(ctx, requestSpec, resp)
+	}
 	return nil
 }
 
+// Fetch sends the request of the executable and returns the response body after it is transformed. Unlike Exec,
+// the response is not logged or saved to the response file.
+func Fetch(ctx *context.Context, e *executable.Executable, inputEnv map[string]string) (string, error) {
+	if e == nil || e.Request == nil {
+		return "", errors.New("executable is not a request")
+	}
+	envMap, err := runner.BuildEnvMap(ctx.Logger, e.Env(), inputEnv, runner.DefaultEnv(ctx, e))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to set parameters to env")
+	}
+	restRequest, err := newRequest(ctx, e, envMap)
+	if err != nil {
+		return "", err
+	}
+	return fetchResponse(ctx.Logger, &restRequest, e.Request, envMap)
+}
+
+func newRequest(ctx *context.Context, e *executable.Executable, envMap map[string]string) (rest.Request, error) {
+	requestSpec := e.Request
+	url := expandEnvVars(envMap, requestSpec.URL)
+	headers := make(map[string]string, len(requestSpec.Headers))
+	for key, value := range requestSpec.Headers {
+		headers[key] = expandEnvVars(envMap, value)
+	}
+	query := make(map[string]string, len(requestSpec.Query))
+	for key, value := range requestSpec.Query {
+		query[expandEnvVars(envMap, key)] = expandEnvVars(envMap, value)
+	}
+	restRequest := rest.Request{
+		URL:     url,
+		Method:  string(requestSpec.Method),
+		Headers: headers,
+		Query:   query,
+		Timeout: requestSpec.Timeout,
+	}
+	var err error
+	restRequest.Client, err = clientConfig(ctx, e, envMap)
+	if err != nil {
+		return rest.Request{}, errors.Wrap(err, "invalid http client config")
+	}
+	if err := setRequestBody(ctx.Logger, e, envMap, &restRequest); err != nil {
+		return rest.Request{}, errors.Wrap(err, "unable to set request body")
+	}
+	return restRequest, nil
+}
+
+// fetchResponse sends the request, checks its assertions, extracts its values and returns the transformed body.
+func fetchResponse(
+	logger io.Logger,
+	req *rest.Request,
+	requestSpec *executable.RequestExecutableType,
+	envMap map[string]string,
+) (string, error) {
+	start := time.Now()
+	var response *rest.Response
+	var err error
+	if requestSpec.Paginate != nil {
+		response, err = sendPaginatedRequest(logger, req, requestSpec, envMap)
+	} else {
+		response, err = sendRequest(logger, req, requestSpec, envMap)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "request failed")
+	}
+	resp := response.Body
+
+	if len(requestSpec.Assert) > 0 || len(requestSpec.Extract) > 0 {
+		respEnv := responseEnv(response, time.Since(start), envMap)
+		if err := checkAssertions(requestSpec.Assert, respEnv); err != nil {
+			return "", err
+		}
+		if err := extractValues(logger, requestSpec.Extract, respEnv); err != nil {
+			return "", errors.Wrap(err, "unable to extract response values")
+		}
+	}
+
+	if requestSpec.TransformResponse != "" {
+		resp, err = executeJQQuery(requestSpec.TransformResponse, resp)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to transform response")
+		}
+	}
+	return resp, nil
+}
+
+func sendRequest(
+	logger io.Logger,
+	req *rest.Request,
+	requestSpec *executable.RequestExecutableType,
+	envMap map[string]string,
+) (*rest.Response, error) {
+	httpResp, err := openResponse(logger, req, requestSpec, envMap)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rest.ReadResponse(httpResp)
+	if err != nil {
+		return nil, err
+	}
+	if requestSpec.GraphQL != nil {
+		if err := checkGraphQLErrors(resp.Body); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// openResponse sends the request and returns the response with its body unread. An error is returned if the
+// response status code is not accepted.
+func openResponse(
+	logger io.Logger,
+	req *rest.Request,
+	requestSpec *executable.RequestExecutableType,
+	envMap map[string]string,
+) (*http.Response, error) {
+	if err := applyAuth(logger, requestSpec.Auth, envMap, req, false); err != nil {
+		return nil, err
+	}
+	if retry := requestSpec.Retry; retry != nil {
+		req.Retry = &rest.RetryConfig{
+			Attempts:    retry.Attempts,
+			StatusCodes: retry.StatusCodes,
+			Backoff:     retry.Backoff,
+			MaxBackoff:  retry.MaxBackoff,
+			OnRetry: func(attempt int, resp *rest.Response, delay time.Duration) {
+				logger.Warnx(
+					fmt.Sprintf("request attempt %d failed, retrying in %s", attempt, delay),
+					"status", resp.StatusCode,
+				)
+			},
+		}
+	}
+	httpResp, err := rest.Stream(req)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := requestSpec.Auth
+	if httpResp.StatusCode == http.StatusUnauthorized && auth != nil && auth.Oauth2ClientCredentials != nil {
+		_ = httpResp.Body.Close()
+		logger.Debugf("request was unauthorized, refreshing oauth2 token")
+		if err := applyAuth(logger, auth, envMap, req, true); err != nil {
+			return nil, err
+		}
+		httpResp, err = rest.Stream(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	accepted := rest.IsStatusCodeAccepted(httpResp.StatusCode, requestSpec.ValidStatusCodes) ||
+		(httpResp.StatusCode == http.StatusPartialContent && hasHeader(req.Headers, rangeHeader))
+	if !accepted {
+		resp, err := rest.ReadResponse(httpResp)
+		if err != nil {
+			return nil, err
+		}
+		return nil, rest.NewStatusError(resp)
+	}
+	return httpResp, nil
+}
+
 func executeJQQuery(query, resp string) (string, error) {
-	var respMap map[string]interface{}
+	var respMap interface{}
 	err := json.Unmarshal([]byte(resp), &respMap)
 	if err != nil {
 		return "", errors.New("response is not a valid JSON string")
@@ -136,13 +302,13 @@ func writeResponseToFile(resp, responseFile string, format executable.RequestRes
 	case "", executable.RequestResponseFileSaveAsRaw:
 		formattedResp = resp
 	case executable.RequestResponseFileSaveAsJson:
-		var js map[string]interface{}
+		var js interface{}
 		if json.Unmarshal([]byte(resp), &js) != nil {
 			return errors.New("response is not a valid JSON string")
 		}
 		formattedResp = resp
 	case executable.RequestResponseFileSaveAsIndentedJson, "formatted-json":
-		var respMap map[string]interface{}
+		var respMap interface{}
 		err := json.Unmarshal([]byte(resp), &respMap)
 		if err != nil {
 			return errors.New("response is not a valid JSON string")
@@ -153,7 +319,7 @@ func writeResponseToFile(resp, responseFile string, format executable.RequestRes
 		}
 		formattedResp = string(formattedStr)
 	case executable.RequestResponseFileSaveAsYaml, executable.RequestResponseFileSaveAsYml:
-		var respMap map[string]interface{}
+		var respMap interface{}
 		err := json.Unmarshal([]byte(resp), &respMap)
 		if err != nil {
 			return errors.New("response is not a valid JSON string")
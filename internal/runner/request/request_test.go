@@ -1,18 +1,35 @@
 package request_test
 
 import (
+	"bytes"
 	stdCtx "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"github.com/jahvon/flow/internal/crypto"
 	"github.com/jahvon/flow/internal/runner"
 	"github.com/jahvon/flow/internal/runner/engine/mocks"
 	"github.com/jahvon/flow/internal/runner/request"
+	"github.com/jahvon/flow/internal/services/store"
+	"github.com/jahvon/flow/internal/vault"
 	testUtils "github.com/jahvon/flow/tests/utils"
 	"github.com/jahvon/flow/types/executable"
 )
@@ -112,4 +129,615 @@ var _ = Describe("Request Runner", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("Exec with auth", func() {
+		It("should send a bearer token resolved from a param", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer my-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Params: executable.ParameterList{{EnvKey: "TOKEN", Text: "my-token"}},
+					URL:    server.URL,
+					Auth:   &executable.RequestAuth{Bearer: &executable.RequestBearerAuth{Token: "$TOKEN"}},
+				},
+			}
+
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+		})
+
+		It("should send an api key as a query parameter", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("api_key") != "abc123" || r.URL.Query().Get("q") != "search" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL + "?q=search",
+					Auth: &executable.RequestAuth{ApiKey: &executable.RequestAPIKeyAuth{
+						Name:  "api_key",
+						In:    executable.RequestAPIKeyAuthInQuery,
+						Value: "abc123",
+					}},
+				},
+			}
+
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+		})
+
+		It("should cache the oauth2 token and refresh it when unauthorized", func() {
+			key, err := crypto.GenerateKey()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vault.RegisterEncryptionKey(key)).To(Succeed())
+			GinkgoT().Setenv(vault.EncryptionKeyEnvVar, key)
+			Expect(vault.NewVault(ctx.Logger).SetSecret("client-secret", "shh")).To(Succeed())
+
+			var tokenRequests, validToken atomic.Int32
+			tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.ParseForm()).To(Succeed())
+				if r.PostForm.Get("client_id") != "my-client" || r.PostForm.Get("client_secret") != "shh" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				n := tokenRequests.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600}`, n)
+			}))
+			defer tokenServer.Close()
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", validToken.Load()) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer apiServer.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: apiServer.URL,
+					Auth: &executable.RequestAuth{Oauth2ClientCredentials: &executable.RequestOAuth2ClientCredentials{
+						TokenURL:        tokenServer.URL,
+						ClientID:        "my-client",
+						ClientSecretRef: "client-secret",
+					}},
+				},
+			}
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(3)
+
+			validToken.Store(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+			Expect(tokenRequests.Load()).To(Equal(int32(1)))
+
+			validToken.Store(2)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+			Expect(tokenRequests.Load()).To(Equal(int32(2)))
+		})
+		It("should request the oauth2 token with the request's client config", func() {
+			key, err := crypto.GenerateKey()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vault.RegisterEncryptionKey(key)).To(Succeed())
+			GinkgoT().Setenv(vault.EncryptionKeyEnvVar, key)
+			Expect(vault.NewVault(ctx.Logger).SetSecret("client-secret", "shh")).To(Succeed())
+
+			tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{"access_token": "tls-token", "expires_in": 3600}`)
+			}))
+			defer tokenServer.Close()
+			apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer tls-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer apiServer.Close()
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+			caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tokenServer.Certificate().Raw})
+			Expect(os.WriteFile(caFile, caPEM, 0600)).To(Succeed())
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL:        apiServer.URL,
+					HttpClient: &executable.RequestExecutableTypeHttpClient{CaFile: caFile},
+					Auth: &executable.RequestAuth{Oauth2ClientCredentials: &executable.RequestOAuth2ClientCredentials{
+						TokenURL:        tokenServer.URL,
+						ClientID:        "my-client",
+						ClientSecretRef: "client-secret",
+					}},
+				},
+			}
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+		})
+	})
+
+	Describe("Exec with assertions", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Request-Id", "abc123")
+				_, _ = w.Write([]byte(`{"items": [{"id": 1, "name": "first"}], "total": 1}`))
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		It("should save extracted values to the process store when assertions pass", func() {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL,
+					Assert: []string{
+						`status == 200 && len(body.items) > 0`,
+						`(body.items | len()) > 0`,
+						`headers["X-Request-Id"] == "abc123"`,
+						`duration < 10000`,
+					},
+					Extract: []executable.RequestExtract{
+						{Key: "first-id", Jq: ".items[0].id"},
+						{Key: "first-item", Jq: ".items[0]"},
+						{Key: "request-id", Expr: `headers["X-Request-Id"]`},
+					},
+				},
+			}
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			str, err := store.NewStore()
+			Expect(err).NotTo(HaveOccurred())
+			defer str.Close()
+			_, err = str.CreateAndSetBucket(store.EnvironmentBucket())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(str.Get("first-id")).To(Equal("1"))
+			Expect(str.Get("first-item")).To(MatchJSON(`{"id": 1, "name": "first"}`))
+			Expect(str.Get("request-id")).To(Equal("abc123"))
+		})
+
+		It("should list each failed assertion with the actual values", func() {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL,
+					Assert: []string{
+						`status == 200`,
+						`body.total > 5`,
+						`body.items[0].name == "second"`,
+					},
+				},
+			}
+			err := requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("2 of 3 assertions failed"))
+			Expect(err.Error()).To(ContainSubstring("- body.total > 5\n  actual: body.total = 1"))
+			Expect(err.Error()).To(ContainSubstring(
+				`- body.items[0].name == "second"` + "\n" + `  actual: body.items[0].name = first`,
+			))
+			Expect(err.Error()).NotTo(ContainSubstring("- status == 200"))
+		})
+	})
+
+	Describe("Exec with pagination", func() {
+		var readResponse func(exec *executable.Executable) string
+
+		BeforeEach(func() {
+			readResponse = func(exec *executable.Executable) string {
+				exec.Request.ResponseFile = &executable.RequestResponseFile{
+					Filename: "pages.json",
+					Dir:      executable.Directory("//"),
+				}
+				exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), ctx.Ctx.CurrentWorkspace.Location(), "", "")
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+				Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+				data, err := os.ReadFile(filepath.Join(ctx.Ctx.CurrentWorkspace.Location(), "pages.json"))
+				Expect(err).NotTo(HaveOccurred())
+				return string(data)
+			}
+		})
+
+		It("should follow the next link header", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("after") {
+				case "":
+					w.Header().Set("Link", `</items?after=2>; rel="next", </items?after=4>; rel="last"`)
+					_, _ = w.Write([]byte(`[1, 2]`))
+				case "2":
+					w.Header().Set("Link", `</items?after=4>; rel="next"`)
+					_, _ = w.Write([]byte(`[3, 4]`))
+				default:
+					_, _ = w.Write([]byte(`[5]`))
+				}
+			}))
+			defer server.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL:      server.URL + "/items",
+					Paginate: &executable.RequestPaginate{Strategy: executable.RequestPaginateStrategyLinkHeader},
+				},
+			}
+			Expect(readResponse(exec)).To(MatchJSON(`[1, 2, 3, 4, 5]`))
+		})
+
+		It("should send the cursor of the previous page", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("next") {
+				case "":
+					_, _ = w.Write([]byte(`{"data": [{"id": "a"}], "meta": {"next": "c1"}}`))
+				case "c1":
+					_, _ = w.Write([]byte(`{"data": [{"id": "b"}], "meta": {"next": null}}`))
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL,
+					Paginate: &executable.RequestPaginate{
+						Strategy:    executable.RequestPaginateStrategyCursor,
+						Items:       ".data",
+						Cursor:      ".meta.next",
+						CursorParam: "next",
+					},
+				},
+			}
+			Expect(readResponse(exec)).To(MatchJSON(`[{"id": "a"}, {"id": "b"}]`))
+		})
+
+		It("should increment the page until a page is empty or max pages is reached", func() {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if r.URL.Query().Get("p") == "3" {
+					_, _ = w.Write([]byte(`{"results": []}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"results": [%q]}`, r.URL.Query().Get("p"))
+			}))
+			defer server.Close()
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL,
+					Paginate: &executable.RequestPaginate{
+						Strategy:  executable.RequestPaginateStrategyPage,
+						Items:     ".results",
+						PageParam: "p",
+					},
+				},
+			}
+			Expect(readResponse(exec)).To(MatchJSON(`["1", "2"]`))
+			Expect(requests.Load()).To(Equal(int32(3)))
+
+			requests.Store(0)
+			exec.Request.Paginate.MaxPages = 1
+			Expect(readResponse(exec)).To(MatchJSON(`["1"]`))
+			Expect(requests.Load()).To(Equal(int32(1)))
+		})
+	})
+
+	Describe("Exec with streamed downloads", func() {
+		var (
+			server  *httptest.Server
+			content []byte
+			ranges  chan string
+			target  string
+		)
+
+		BeforeEach(func() {
+			content = make([]byte, 256*1024)
+			for i := range content {
+				content[i] = byte(i % 251)
+			}
+			ranges = make(chan string, 1)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges <- r.Header.Get("Range")
+				http.ServeContent(w, r, "artifact.bin", time.Time{}, bytes.NewReader(content))
+			}))
+			DeferCleanup(server.Close)
+			target = filepath.Join(ctx.Ctx.CurrentWorkspace.Location(), "artifact.bin")
+			DeferCleanup(func() {
+				_ = os.Remove(target)
+				_ = os.Remove(target + ".part")
+			})
+		})
+
+		newExec := func(checksum string) *executable.Executable {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL: server.URL,
+					ResponseFile: &executable.RequestResponseFile{
+						Filename: "artifact.bin",
+						Dir:      executable.Directory("//"),
+						Sha256:   checksum,
+					},
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), ctx.Ctx.CurrentWorkspace.Location(), "", "")
+			return exec
+		}
+
+		It("should stream the response to the file and verify the checksum", func() {
+			sum := sha256.Sum256(content)
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+			Expect(requestRnr.Exec(ctx.Ctx, newExec(hex.EncodeToString(sum[:])), mockEngine, nil)).To(Succeed())
+			Expect(<-ranges).To(BeEmpty())
+
+			data, err := os.ReadFile(target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(content))
+			Expect(target + ".part").NotTo(BeAnExistingFile())
+		})
+
+		It("should resume a partial download with a range request", func() {
+			Expect(os.WriteFile(target+".part", content[:1000], 0600)).To(Succeed())
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+			Expect(requestRnr.Exec(ctx.Ctx, newExec(""), mockEngine, nil)).To(Succeed())
+			Expect(<-ranges).To(Equal("bytes=1000-"))
+
+			data, err := os.ReadFile(target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(content))
+		})
+
+		It("should not apply the timeout to reading a slow response body", func() {
+			slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				chunk := len(content) / 4
+				for i := 0; i < len(content); i += chunk {
+					_, _ = w.Write(content[i : i+chunk])
+					w.(http.Flusher).Flush()
+					time.Sleep(100 * time.Millisecond)
+				}
+			}))
+			DeferCleanup(slowServer.Close)
+			exec := newExec("")
+			exec.Request.URL = slowServer.URL
+			exec.Request.Timeout = 150 * time.Millisecond
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, nil)).To(Succeed())
+
+			data, err := os.ReadFile(target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(content))
+		})
+
+		It("should remove the file when the checksum does not match", func() {
+			err := requestRnr.Exec(ctx.Ctx, newExec(strings.Repeat("0", 64)), mockEngine, nil)
+			Expect(err).To(MatchError(ContainSubstring("sha256 checksum mismatch")))
+			Expect(target).NotTo(BeAnExistingFile())
+			Expect(target + ".part").NotTo(BeAnExistingFile())
+		})
+	})
+
+	Describe("Exec with graphql", func() {
+		var (
+			server   *httptest.Server
+			received chan *http.Request
+			bodies   chan string
+			response string
+		)
+
+		BeforeEach(func() {
+			received = make(chan *http.Request, 1)
+			bodies = make(chan string, 1)
+			response = `{"data": {"user": {"name": "flow"}}}`
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				received <- r
+				bodies <- string(body)
+				_, _ = w.Write([]byte(response))
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		It("should post the operation with expanded variables", func() {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Params: executable.ParameterList{{EnvKey: "USER_ID", Text: "42"}},
+					URL:    server.URL,
+					GraphQL: &executable.RequestGraphQL{
+						Query:         "query GetUser($id: ID!) { user(id: $id) { name } }",
+						Variables:     executable.RequestGraphQLVariables{"id": "$USER_ID", "limit": 10},
+						OperationName: "GetUser",
+					},
+				},
+			}
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(<-bodies).To(MatchJSON(`{
+				"query": "query GetUser($id: ID!) { user(id: $id) { name } }",
+				"variables": {"id": "42", "limit": 10},
+				"operationName": "GetUser"
+			}`))
+		})
+
+		It("should send the query from a file as query parameters for GET requests", func() {
+			wsDir := ctx.Ctx.CurrentWorkspace.Location()
+			Expect(os.WriteFile(filepath.Join(wsDir, "user.graphql"), []byte("{ viewer { name } }"), 0600)).To(Succeed())
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Method:  executable.RequestExecutableTypeMethodGET,
+					URL:     server.URL,
+					GraphQL: &executable.RequestGraphQL{QueryFile: "//user.graphql"},
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", "")
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			Expect(r.Method).To(Equal(http.MethodGet))
+			Expect(r.URL.Query().Get("query")).To(Equal("{ viewer { name } }"))
+			Expect(<-bodies).To(BeEmpty())
+		})
+
+		It("should fail when the response contains errors", func() {
+			response = `{"data": null, "errors": [{"message": "user not found", "path": ["user", 0]}]}`
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL:     server.URL,
+					GraphQL: &executable.RequestGraphQL{Query: "{ user { name } }"},
+				},
+			}
+			err := requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("graphql response contains errors:\n- user not found (path: user.0)")))
+		})
+	})
+
+	Describe("Exec with request bodies", func() {
+		var (
+			server   *httptest.Server
+			received chan *http.Request
+			bodies   chan string
+		)
+
+		BeforeEach(func() {
+			received = make(chan *http.Request, 1)
+			bodies = make(chan string, 1)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				received <- r
+				bodies <- string(body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(server.Close)
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+		})
+
+		It("should send query params and a urlencoded form with env expansion", func() {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Params: executable.ParameterList{{EnvKey: "NAME", Text: "flow"}},
+					Method: executable.RequestExecutableTypeMethodPOST,
+					URL:    server.URL,
+					Query:  executable.RequestExecutableTypeQuery{"name": "$NAME"},
+					Form:   executable.RequestExecutableTypeForm{"greeting": "hello $NAME"},
+				},
+			}
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			Expect(r.URL.Query().Get("name")).To(Equal("flow"))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/x-www-form-urlencoded"))
+			Expect(<-bodies).To(Equal("greeting=hello+flow"))
+		})
+
+		It("should marshal a structured json body", func() {
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Params: executable.ParameterList{{EnvKey: "NAME", Text: "flow"}},
+					Method: executable.RequestExecutableTypeMethodPOST,
+					URL:    server.URL,
+					JSON: map[string]interface{}{
+						"name":  "$NAME",
+						"tags":  []interface{}{"a", "$NAME"},
+						"count": 2,
+					},
+				},
+			}
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(<-bodies).To(MatchJSON(`{"name": "flow", "tags": ["a", "flow"], "count": 2}`))
+		})
+
+		It("should stream multipart fields and files from the executable's directory", func() {
+			wsPath := ctx.Ctx.CurrentWorkspace.Location()
+			Expect(os.WriteFile(filepath.Join(wsPath, "upload.txt"), []byte("file contents"), 0600)).To(Succeed())
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodPOST,
+					URL:    server.URL,
+					Multipart: []executable.RequestMultipartPart{
+						{Name: "field", Value: "value"},
+						{Name: "upload", File: "upload.txt"},
+					},
+				},
+			}
+			exec.SetContext(
+				ctx.Ctx.CurrentWorkspace.AssignedName(), wsPath, "", filepath.Join(wsPath, "test.flow"),
+			)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mediaType).To(Equal("multipart/form-data"))
+			form, err := multipart.NewReader(strings.NewReader(<-bodies), params["boundary"]).ReadForm(1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(form.Value["field"]).To(Equal([]string{"value"}))
+			Expect(form.File["upload"]).To(HaveLen(1))
+			Expect(form.File["upload"][0].Filename).To(Equal("upload.txt"))
+		})
+
+		It("should stream the body file", func() {
+			wsPath := ctx.Ctx.CurrentWorkspace.Location()
+			Expect(os.WriteFile(filepath.Join(wsPath, "body.json"), []byte(`{"from": "file"}`), 0600)).To(Succeed())
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					Method:   executable.RequestExecutableTypeMethodPUT,
+					URL:      server.URL,
+					BodyFile: "//body.json",
+				},
+			}
+			exec.SetContext(
+				ctx.Ctx.CurrentWorkspace.AssignedName(), wsPath, "", filepath.Join(wsPath, "test.flow"),
+			)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			r := <-received
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(<-bodies).To(Equal(`{"from": "file"}`))
+		})
+	})
+
+	Describe("Exec with viewResponse", func() {
+		It("should print the response as a table when the TUI is disabled", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[{"name": "api", "status": "up"}, {"name": "db", "status": "down"}]`))
+			}))
+			defer server.Close()
+			out, err := os.CreateTemp(GinkgoT().TempDir(), "stdout")
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(ctx.Ctx.StdIn(), out)
+
+			exec := &executable.Executable{
+				Request: &executable.RequestExecutableType{
+					URL:          server.URL,
+					ViewResponse: executable.ViewFormatTable,
+				},
+			}
+			exec.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), ctx.Ctx.CurrentWorkspace.Location(), "", "")
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string))).To(Succeed())
+
+			data, err := os.ReadFile(out.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("name  status\napi   up\ndb    down\n"))
+		})
+	})
 })
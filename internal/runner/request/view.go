@@ -0,0 +1,42 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/io/viewer"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// viewResponse opens the response in the viewer for the request's viewResponse format. The formatted response is
+// printed when the interactive UI is disabled.
+func viewResponse(ctx *context.Context, spec *executable.RequestExecutableType, body string) error {
+	name := "response"
+	if spec.ResponseFile != nil && spec.ResponseFile.Filename != "" {
+		// The filename is used to detect CSV and TSV responses.
+		name = spec.ResponseFile.Filename
+	}
+	if !ctx.ShowTUI() {
+		formatted, err := viewer.Format(spec.ViewResponse, name, body)
+		if err != nil {
+			return errors.Wrapf(err, "unable to view response as %s", spec.ViewResponse)
+		}
+		_, err = fmt.Fprint(ctx.StdOut(), formatted)
+		return err
+	}
+
+	view, err := viewer.NewView(ctx.TUIContainer.RenderState(), spec.ViewResponse, name, body)
+	if err != nil {
+		return errors.Wrapf(err, "unable to view response as %s", spec.ViewResponse)
+	}
+	if !ctx.TUIContainer.Ready() {
+		if err := ctx.TUIContainer.Start(); err != nil {
+			return errors.Wrap(err, "unable to start the terminal UI")
+		}
+		defer ctx.TUIContainer.WaitForExit()
+	}
+	ctx.TUIContainer.SetState("request", spec.URL)
+	return ctx.TUIContainer.SetView(view)
+}
@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"maps"
 	"os"
-	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/jahvon/flow/internal/context"
 	"github.com/jahvon/flow/internal/runner"
 	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/internal/runner/prompt"
 	"github.com/jahvon/flow/internal/services/expr"
 	"github.com/jahvon/flow/internal/services/store"
 	argUtils "github.com/jahvon/flow/internal/utils/args"
@@ -51,32 +51,50 @@ func (r *serialRunner) Exec(
 		if err != nil {
 			return err
 		}
-		defer str.Close()
 		if err := str.CreateBucket(store.EnvironmentBucket()); err != nil {
+			_ = str.Close()
 			return err
 		}
-		return handleExec(ctx, e, eng, serialSpec, inputEnv, str)
+		dm, err := str.GetAll()
+		// The store is closed before running the child executables so that they are able to open it.
+		_ = str.Close()
+		if err != nil {
+			return err
+		}
+		return handleExec(ctx, e, eng, serialSpec, inputEnv, dm)
 	}
 	return fmt.Errorf("no serial executables to run")
 }
 
+//nolint:gocognit
 func handleExec(
 	ctx *context.Context,
 	parent *executable.Executable,
 	eng engine.Engine,
 	serialSpec *executable.SerialExecutableType,
 	promptedEnv map[string]string,
-	str store.Store,
+	storeData map[string]string,
 ) error {
-	dm, err := str.GetAll()
-	if err != nil {
-		return err
-	}
-	dataMap := expr.ExpressionEnv(ctx, parent, dm, promptedEnv)
+	dataMap := expr.ExpressionEnv(ctx, parent, storeData, promptedEnv)
+	// answers holds the answers of the prompt steps that have run. They are added to the env of the steps that
+	// follow, and the conditions of those steps are evaluated when the step is run so that they can use them.
+	answers := make(map[string]string)
+	var prompted bool
 
 	var execs []engine.Exec
+	var services []*executable.Executable
 	for i, refConfig := range serialSpec.Execs {
-		if refConfig.If != "" {
+		if refConfig.Prompt != nil {
+			prompted = true
+			execs = append(execs, engine.Exec{
+				ID: fmt.Sprintf("prompt-%d", i+1),
+				Function: func() error {
+					return runPromptStep(ctx, parent, i, refConfig, serialSpec, promptedEnv, storeData, answers)
+				},
+			})
+			continue
+		}
+		if refConfig.If != "" && !prompted {
 			truthy, err := expr.IsTruthy(refConfig.If, &dataMap)
 			if err != nil {
 				return err
@@ -97,13 +115,18 @@ func handleExec(
 			}
 		case refConfig.Cmd != "":
 			exec = execUtils.ExecutableForCmd(parent, refConfig.Cmd, i)
+		case refConfig.Wait != nil:
+			exec = execUtils.ExecutableForWait(parent, refConfig.Wait, i)
 		default:
-			return errors.New("serial executable must have a ref or cmd")
+			return errors.New("serial executable must have a ref, cmd, prompt, or wait")
 		}
 		ctx.Logger.Debugf("executing %s (%d/%d)", exec.Ref(), i+1, len(serialSpec.Execs))
+		if exec.Service != nil {
+			services = append(services, exec)
+		}
 
 		execPromptedEnv := make(map[string]string)
-		maps.Copy(promptedEnv, execPromptedEnv)
+		maps.Copy(execPromptedEnv, promptedEnv)
 		if len(refConfig.Args) > 0 {
 			a, err := argUtils.ProcessArgs(exec, refConfig.Args, execPromptedEnv)
 			if err != nil {
@@ -114,19 +137,105 @@ func handleExec(
 		fields := map[string]interface{}{"step": exec.ID()}
 		exec.Exec.SetLogFields(fields)
 
+		checkCondition := prompted && refConfig.If != ""
 		runExec := func() error {
-			return runSerialExecFunc(ctx, i, refConfig, exec, eng, execPromptedEnv, serialSpec)
+			if checkCondition {
+				truthy, err := stepConditionMet(ctx, parent, refConfig.If, promptedEnv, storeData, answers)
+				if err != nil {
+					return err
+				}
+				if !truthy {
+					ctx.Logger.Debugf("skipping execution %d/%d", i+1, len(serialSpec.Execs))
+					return nil
+				}
+			}
+			env := maps.Clone(execPromptedEnv)
+			maps.Copy(env, answers)
+			return runSerialExecFunc(ctx, i, refConfig, exec, eng, env, serialSpec)
 		}
 
 		execs = append(execs, engine.Exec{ID: exec.Ref().String(), Function: runExec, MaxRetries: refConfig.Retries})
 	}
 	results := eng.Execute(ctx.Ctx, execs, engine.WithMode(engine.Serial), engine.WithFailFast(parent.Serial.FailFast))
+	runner.StopServices(ctx, services)
 	if results.HasErrors() {
 		return errors.New(results.String())
 	}
 	return nil
 }
 
+// runPromptStep asks the prompt's questions and saves the answers for the steps that follow.
+func runPromptStep(
+	ctx *context.Context,
+	parent *executable.Executable,
+	step int,
+	refConfig executable.SerialRefConfig,
+	serialSpec *executable.SerialExecutableType,
+	promptedEnv, storeData, answers map[string]string,
+) error {
+	if refConfig.If != "" {
+		truthy, err := stepConditionMet(ctx, parent, refConfig.If, promptedEnv, storeData, answers)
+		if err != nil {
+			return err
+		}
+		if !truthy {
+			ctx.Logger.Debugf("skipping prompt %d/%d", step+1, len(serialSpec.Execs))
+			return nil
+		}
+	}
+	ctx.Logger.Debugf("prompting for input (%d/%d)", step+1, len(serialSpec.Execs))
+	stepAnswers, err := prompt.Ask(ctx, refConfig.Prompt)
+	if err != nil {
+		return err
+	}
+	if err := saveAnswers(stepAnswers); err != nil {
+		return errors.Wrap(err, "unable to store prompt answers")
+	}
+	// The env passed to the steps only sets their params, so the answers are set in the process's env instead.
+	for key, answer := range stepAnswers {
+		if err := os.Setenv(key, answer); err != nil {
+			return errors.Wrap(err, "unable to set prompt answers to env")
+		}
+	}
+	maps.Copy(answers, stepAnswers)
+	return nil
+}
+
+// saveAnswers sets the answers in the process store so that they can be read by the executables that follow.
+func saveAnswers(answers map[string]string) error {
+	s, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	if _, err := s.CreateAndSetBucket(store.EnvironmentBucket()); err != nil {
+		return err
+	}
+	for key, answer := range answers {
+		if err := s.Set(key, answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepConditionMet evaluates a step's condition with the answers of the prompts that have run.
+func stepConditionMet(
+	ctx *context.Context,
+	parent *executable.Executable,
+	condition string,
+	promptedEnv, storeData, answers map[string]string,
+) (bool, error) {
+	env := make(map[string]string)
+	maps.Copy(env, promptedEnv)
+	maps.Copy(env, answers)
+	data := make(map[string]string)
+	maps.Copy(data, storeData)
+	maps.Copy(data, answers)
+	dataMap := expr.ExpressionEnv(ctx, parent, data, env)
+	return expr.IsTruthy(condition, &dataMap)
+}
+
 func runSerialExecFunc(
 	ctx *context.Context,
 	step int,
@@ -140,18 +249,14 @@ func runSerialExecFunc(
 	if err != nil {
 		return err
 	}
-	if step < len(serialSpec.Execs) && refConfig.ReviewRequired {
-		ctx.Logger.Println("Do you want to proceed with the next execution? (y/n)")
-		if !inputConfirmed(os.Stdin) {
+	if step < len(serialSpec.Execs)-1 && refConfig.ReviewRequired {
+		confirmed, err := prompt.Confirm(ctx, "Do you want to proceed with the next execution?", "")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			return fmt.Errorf("stopping runner early (%d/%d)", step+1, len(serialSpec.Execs))
 		}
 	}
 	return nil
 }
-
-func inputConfirmed(in *os.File) bool {
-	var response string
-	_, _ = fmt.Fscanf(in, response)
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes"
-}
@@ -3,12 +3,15 @@ package serial_test
 import (
 	stdCtx "context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"github.com/jahvon/flow/internal/context"
 	"github.com/jahvon/flow/internal/runner"
 	"github.com/jahvon/flow/internal/runner/engine"
 	"github.com/jahvon/flow/internal/runner/engine/mocks"
@@ -139,4 +142,138 @@ var _ = Describe("SerialRunner", func() {
 			Expect(serialRnr.Exec(ctx.Ctx, rootExec, mockEngine, make(map[string]string))).To(Succeed())
 		})
 	})
+
+	When("Exec with prompt steps", func() {
+		var rootExec *executable.Executable
+
+		BeforeEach(func() {
+			rootExec = &executable.Executable{
+				Verb: "deploy",
+				Name: "app",
+				Serial: &executable.SerialExecutableType{
+					Execs: executable.SerialRefConfigList{
+						{Prompt: &executable.PromptConfig{Fields: []executable.PromptField{
+							{
+								Key:     "DEPLOY_ENV",
+								Type:    executable.PromptFieldTypeSelect,
+								Options: []string{"staging", "production"},
+								Default: "staging",
+							},
+							{Key: "REGIONS", Type: executable.PromptFieldTypeMultiselect, Options: []string{"us", "eu"}},
+						}}},
+						{Cmd: "echo deploying", If: `env["DEPLOY_ENV"] == "production"`},
+						{Cmd: "echo $DEPLOY_ENV"},
+					},
+				},
+			}
+			ws := ctx.Ctx.CurrentWorkspace
+			rootExec.SetContext(ws.AssignedName(), ws.Location(), "examples", filepath.Join(ws.Location(), "app.flow"))
+			ctx.RunnerMock.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+		})
+
+		expectRuns := func(envs *[]map[string]string) {
+			ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *context.Context, _ *executable.Executable, _ engine.Engine, env map[string]string) error {
+					*envs = append(*envs, env)
+					return nil
+				}).AnyTimes()
+		}
+
+		It("should use the answers file and default answers", func() {
+			answersFile := filepath.Join(GinkgoT().TempDir(), "answers.yaml")
+			Expect(os.WriteFile(answersFile, []byte("DEPLOY_ENV: production\nREGIONS: [us, eu]\n"), 0600)).To(Succeed())
+			GinkgoT().Setenv(executable.AnswersFileEnv, answersFile)
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			Expect(envs).To(HaveLen(2))
+			Expect(envs[1]).To(HaveKeyWithValue("DEPLOY_ENV", "production"))
+			Expect(envs[1]).To(HaveKeyWithValue("REGIONS", "us,eu"))
+		})
+
+		It("should skip steps based on the default answers when confirmations are assumed", func() {
+			GinkgoT().Setenv(executable.AssumeYesEnv, "true")
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			Expect(envs).To(HaveLen(1))
+			Expect(envs[0]).To(HaveKeyWithValue("DEPLOY_ENV", "staging"))
+			Expect(envs[0]).To(HaveKeyWithValue("REGIONS", ""))
+		})
+
+		It("should set the answers in the env of the steps that follow", func() {
+			GinkgoT().Setenv(executable.AssumeYesEnv, "true")
+			GinkgoT().Setenv("DEPLOY_ENV", "")
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			Expect(os.Getenv("DEPLOY_ENV")).To(Equal("staging"))
+		})
+
+		It("should pass the prompted env to each step", func() {
+			GinkgoT().Setenv(executable.AssumeYesEnv, "true")
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			promptedEnv := map[string]string{"REGION": "eu"}
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), promptedEnv)).To(Succeed())
+			Expect(envs).To(HaveLen(1))
+			Expect(envs[0]).To(HaveKeyWithValue("REGION", "eu"))
+		})
+
+		It("should read answers from piped input", func() {
+			in, err := os.CreateTemp(GinkgoT().TempDir(), "input")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.WriteString("production\neu\n")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.Seek(0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(in, ctx.Ctx.StdOut())
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			Expect(envs).To(HaveLen(2))
+			Expect(envs[0]).To(HaveKeyWithValue("REGIONS", "eu"))
+		})
+
+		It("should read the answers of each prompt step from piped input", func() {
+			rootExec.Serial.Execs = append(rootExec.Serial.Execs, executable.SerialRefConfig{
+				Prompt: &executable.PromptConfig{Fields: []executable.PromptField{
+					{Key: "VERSION", Type: executable.PromptFieldTypeText},
+				}},
+			}, executable.SerialRefConfig{Cmd: "echo $VERSION"})
+			in, err := os.CreateTemp(GinkgoT().TempDir(), "input")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.WriteString("production\neu\nv2\n")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.Seek(0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(in, ctx.Ctx.StdOut())
+
+			var envs []map[string]string
+			expectRuns(&envs)
+			Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			Expect(envs).To(HaveLen(3))
+			Expect(envs[2]).To(HaveKeyWithValue("DEPLOY_ENV", "production"))
+			Expect(envs[2]).To(HaveKeyWithValue("VERSION", "v2"))
+		})
+
+		It("should fail when an answer is not valid", func() {
+			in, err := os.CreateTemp(GinkgoT().TempDir(), "input")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.WriteString("development\n\n")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = in.Seek(0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Ctx.SetIO(in, ctx.Ctx.StdOut())
+
+			ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			err = serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("development is not an option")))
+		})
+	})
 })
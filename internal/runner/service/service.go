@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/internal/services/background"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// logTailLines is the number of lines of the service's output included in the error when it fails to start.
+const logTailLines = 10
+
+type serviceRunner struct{}
+
+func NewRunner() runner.Runner {
+	return &serviceRunner{}
+}
+
+func (r *serviceRunner) Name() string {
+	return "service"
+}
+
+func (r *serviceRunner) IsCompatible(executable *executable.Executable) bool {
+	if executable == nil || executable.Service == nil {
+		return false
+	}
+	return true
+}
+
+func (r *serviceRunner) Exec(
+	ctx *context.Context,
+	e *executable.Executable,
+	_ engine.Engine,
+	inputEnv map[string]string,
+) error {
+	serviceSpec := e.Service
+	defaultEnv := runner.DefaultEnv(ctx, e)
+	envMap, err := runner.BuildEnvMap(ctx.Logger, e.Env(), inputEnv, defaultEnv)
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	envList, err := runner.BuildEnvList(ctx.Logger, e.Env(), inputEnv, defaultEnv)
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	envList = append(os.Environ(), envList...)
+	targetDir, isTmp, err := serviceSpec.Dir.ExpandDirectory(
+		ctx.Logger,
+		e.WorkspacePath(),
+		e.FlowFilePath(),
+		ctx.ProcessTmpDir,
+		envMap,
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to expand directory")
+	} else if isTmp {
+		ctx.ProcessTmpDir = targetDir
+	}
+
+	ref := e.Ref().String()
+	record, err := background.Get(ref)
+	if err != nil {
+		return err
+	}
+	var exited <-chan error
+	if record != nil && record.Running() {
+		ctx.Logger.Infof("%s is already running (pid %d)", ref, record.PID)
+	} else {
+		if err := filesystem.EnsureServicesDir(); err != nil {
+			return err
+		}
+		replacer := strings.NewReplacer(" ", "_", "/", "_", ":", "_")
+		record, exited, err = background.Start(background.StartOptions{
+			Ref:         ref,
+			Cmd:         serviceSpec.Cmd,
+			Dir:         targetDir,
+			Env:         envList,
+			LogFile:     filepath.Join(filesystem.ServicesDir(), replacer.Replace(ref)+".log"),
+			StopTimeout: serviceSpec.GetStopTimeout(),
+		})
+		if err != nil {
+			return err
+		}
+		ctx.Logger.Debugf("started %s (pid %d)", ref, record.PID)
+	}
+
+	if serviceSpec.Ready != nil {
+		probe := *serviceSpec.Ready
+		probe.SetDefaults()
+		var logExpr *regexp.Regexp
+		if probe.Log != "" {
+			logExpr = regexp.MustCompile(probe.Log)
+		}
+		err := background.WaitReady(ctx.Ctx, background.Probe{
+			HTTP:     probe.Http,
+			Status:   probe.Status,
+			TCP:      probe.Tcp,
+			Log:      logExpr,
+			Cmd:      probe.Cmd,
+			Dir:      targetDir,
+			Env:      envList,
+			Timeout:  probe.Timeout,
+			Interval: probe.Interval,
+		}, record, exited)
+		if err != nil {
+			tail := background.LogTail(record, logTailLines)
+			if exited != nil {
+				// Only services that were started by this run are stopped.
+				if stopErr := background.Stop(record); stopErr != nil {
+					ctx.Logger.Error(stopErr, "unable to stop service")
+				}
+			}
+			if tail != "" {
+				return fmt.Errorf("%s failed to start: %w\n%s", ref, err, tail)
+			}
+			return fmt.Errorf("%s failed to start: %w", ref, err)
+		}
+	}
+	ctx.Logger.Infof("%s is ready (pid %d); output is saved to %s", ref, record.PID, record.LogFile)
+	return nil
+}
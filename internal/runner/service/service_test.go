@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	stdCtx "context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/service"
+	"github.com/jahvon/flow/internal/services/background"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestMain(m *testing.M) {
+	// Services are started by running the test binary again with the hidden service command's arguments.
+	if args := os.Args[1:]; len(args) == 4 && slices.Equal(args, background.ServiceCmdArgs(args[3])) {
+		if err := background.RunCmd(stdCtx.Background(), args[3]); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Service Runner Suite")
+}
+
+var _ = Describe("Service Runner", func() {
+	var (
+		ctx        *context.Context
+		serviceRnr runner.Runner
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContext(stdCtx.Background(), GinkgoT())
+		serviceRnr = service.NewRunner()
+	})
+
+	AfterEach(func() {
+		records, err := background.List()
+		Expect(err).NotTo(HaveOccurred())
+		for _, record := range records {
+			Expect(background.Stop(record)).To(Succeed())
+		}
+	})
+
+	newExec := func(cmd string, probe *executable.ReadinessProbe) *executable.Executable {
+		e := &executable.Executable{
+			Verb:    "start",
+			Name:    "svc",
+			Service: &executable.ServiceExecutableType{Cmd: cmd, Ready: probe, StopTimeout: time.Second},
+		}
+		ws := ctx.CurrentWorkspace
+		e.SetContext(ws.AssignedName(), ws.Location(), "ns", ws.Location()+"/svc.flow")
+		return e
+	}
+
+	Context("IsCompatible", func() {
+		It("should return false when executable is nil", func() {
+			Expect(serviceRnr.IsCompatible(nil)).To(BeFalse())
+		})
+
+		It("should return true when executable type is service", func() {
+			Expect(serviceRnr.IsCompatible(newExec("sleep 1", nil))).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		It("should start the service and wait for its output", func() {
+			e := newExec("echo starting; sleep 0.2; echo listening on 8080; sleep 30", &executable.ReadinessProbe{
+				Log:      "listening on [0-9]+",
+				Interval: 50 * time.Millisecond,
+			})
+			Expect(serviceRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+
+			record, err := background.Get(e.Ref().String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).NotTo(BeNil())
+			Expect(record.Running()).To(BeTrue())
+			Expect(background.LogTail(record, 1)).To(Equal("listening on 8080"))
+		})
+
+		It("should wait for the service to accept connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer listener.Close()
+			e := newExec("sleep 30", &executable.ReadinessProbe{Tcp: listener.Addr().String()})
+			Expect(serviceRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+		})
+
+		It("should stop the service when it is not ready in time", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+			e := newExec("sleep 30", &executable.ReadinessProbe{
+				Http:     server.URL,
+				Timeout:  300 * time.Millisecond,
+				Interval: 50 * time.Millisecond,
+			})
+			err := serviceRnr.Exec(ctx, e, nil, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("was not ready after 300ms")))
+
+			record, err := background.Get(e.Ref().String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(BeNil())
+		})
+
+		It("should return the output when the service exits before it is ready", func() {
+			e := newExec("echo address in use; exit 1", &executable.ReadinessProbe{Tcp: "127.0.0.1:1"})
+			err := serviceRnr.Exec(ctx, e, nil, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("exited before it was ready")))
+			Expect(err).To(MatchError(ContainSubstring("address in use")))
+		})
+
+		It("should not start a service that is already running", func() {
+			e := newExec("sleep 30", nil)
+			Expect(serviceRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			first, err := background.Get(e.Ref().String())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(serviceRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			second, err := background.Get(e.Ref().String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.PID).To(Equal(first.PID))
+		})
+	})
+
+	Describe("StopServices", func() {
+		It("should stop the services started by the process", func() {
+			e := newExec("sleep 30", nil)
+			kept := newExec("sleep 30", nil)
+			kept.Name = "kept"
+			kept.Service.KeepRunning = true
+			Expect(serviceRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(serviceRnr.Exec(ctx, kept, nil, make(map[string]string))).To(Succeed())
+			record, err := background.Get(e.Ref().String())
+			Expect(err).NotTo(HaveOccurred())
+
+			runner.StopServices(ctx, executable.ExecutableList{e, kept})
+			Eventually(record.Running).Should(BeFalse())
+			records, err := background.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Ref).To(Equal(kept.Ref().String()))
+		})
+	})
+})
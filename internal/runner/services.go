@@ -0,0 +1,32 @@
+package runner
+
+import (
+	"os"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/services/background"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// StopServices stops the services of the executables that were started by this process, in reverse order. Services
+// that are set to keep running are not stopped. It is called when a serial or parallel executable finishes.
+func StopServices(ctx *context.Context, execs []*executable.Executable) {
+	for i := len(execs) - 1; i >= 0; i-- {
+		e := execs[i]
+		if e.Service == nil || e.Service.KeepRunning {
+			continue
+		}
+		ref := e.Ref().String()
+		record, err := background.Get(ref)
+		if err != nil {
+			ctx.Logger.Error(err, "unable to get service record")
+			continue
+		} else if record == nil || record.Owner != os.Getpid() {
+			continue
+		}
+		ctx.Logger.Debugf("stopping %s (pid %d)", ref, record.PID)
+		if err := background.Stop(record); err != nil {
+			ctx.Logger.Error(err, "unable to stop service")
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package wait
+
+import (
+	stdCtx "context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/services/check"
+	"github.com/jahvon/flow/internal/services/expr"
+	"github.com/jahvon/flow/internal/services/store"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// errInvalidCondition is returned when the condition can never be met, so waiting stops immediately.
+var errInvalidCondition = errors.New("invalid wait condition")
+
+// condition is the expanded wait condition. Only one of http, tcp, file, cmd, or expr is set.
+type condition struct {
+	ctx *context.Context
+	e   *executable.Executable
+
+	http   string
+	status int
+	tcp    string
+	file   string
+	absent bool
+	cmd    string
+	expr   string
+
+	dir    string
+	env    []string
+	envMap map[string]string
+	// checkTimeout limits how long a single HTTP request or connection attempt can take.
+	checkTimeout time.Duration
+}
+
+// check returns nil when the condition is met, otherwise an error describing why it is not.
+func (c *condition) check(ctx stdCtx.Context) error {
+	switch {
+	case c.http != "":
+		err := check.HTTP(ctx, c.http, c.status, c.checkTimeout)
+		if errors.Is(err, check.ErrInvalidRequest) {
+			return fmt.Errorf("%w: %w", errInvalidCondition, err)
+		}
+		return err
+	case c.tcp != "":
+		return check.TCP(ctx, c.tcp, c.checkTimeout)
+	case c.file != "":
+		path := c.file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.dir, path)
+		}
+		_, err := os.Stat(path)
+		switch {
+		case c.absent && err == nil:
+			return fmt.Errorf("%s exists", path)
+		case c.absent && os.IsNotExist(err):
+			return nil
+		case !c.absent && os.IsNotExist(err):
+			return fmt.Errorf("%s does not exist", path)
+		}
+		return err
+	case c.cmd != "":
+		return check.Cmd(ctx, c.cmd, c.dir, c.env)
+	case c.expr != "":
+		data, err := storeData()
+		if err != nil {
+			return err
+		}
+		dataMap := expr.ExpressionEnv(c.ctx, c.e, data, c.envMap)
+		truthy, err := expr.IsTruthy(c.expr, &dataMap)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidCondition, err)
+		} else if !truthy {
+			return errors.New("expression is false")
+		}
+		return nil
+	default:
+		return errInvalidCondition
+	}
+}
+
+// storeData reads the stored data again so that values set while waiting are seen.
+func storeData() (map[string]string, error) {
+	bucket := store.EnvironmentBucket()
+	str, err := store.NewBucketStore(bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer str.Close()
+	if err := str.CreateBucket(bucket); err != nil {
+		return nil, err
+	}
+	return str.GetAll()
+}
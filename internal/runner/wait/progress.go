@@ -0,0 +1,67 @@
+package wait
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+
+	"github.com/jahvon/flow/internal/context"
+)
+
+// progress shows a spinner with the elapsed time while waiting. The spinner is drawn when the interactive UI is
+// enabled and the output is a terminal, otherwise the message is logged once.
+type progress struct {
+	started  time.Time
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func startProgress(ctx *context.Context, message string) *progress {
+	p := &progress{started: time.Now(), done: make(chan struct{})}
+	out := ctx.StdOut()
+	if !ctx.ShowTUI() || !isTerminal(out) {
+		ctx.Logger.Infof("%s...", message)
+		return p
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		frames := spinner.Dot.Frames
+		ticker := time.NewTicker(spinner.Dot.FPS)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			_, _ = fmt.Fprintf(out, "\r%s%s (%s)", frames[i%len(frames)], message, p.elapsed())
+			select {
+			case <-p.done:
+				// Clear the line so that the messages that follow are not appended to the spinner.
+				_, _ = fmt.Fprint(out, "\r\033[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return p
+}
+
+func (p *progress) elapsed() time.Duration {
+	return time.Since(p.started).Round(time.Second)
+}
+
+func (p *progress) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+	})
+}
+
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
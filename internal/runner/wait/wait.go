@@ -0,0 +1,117 @@
+package wait
+
+import (
+	stdCtx "context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type waitRunner struct{}
+
+func NewRunner() runner.Runner {
+	return &waitRunner{}
+}
+
+func (r *waitRunner) Name() string {
+	return "wait"
+}
+
+func (r *waitRunner) IsCompatible(executable *executable.Executable) bool {
+	if executable == nil || executable.Wait == nil {
+		return false
+	}
+	return true
+}
+
+func (r *waitRunner) Exec(
+	ctx *context.Context,
+	e *executable.Executable,
+	_ engine.Engine,
+	inputEnv map[string]string,
+) error {
+	waitSpec := *e.Wait
+	waitSpec.SetDefaults()
+	defaultEnv := runner.DefaultEnv(ctx, e)
+	envMap, err := runner.BuildEnvMap(ctx.Logger, e.Env(), inputEnv, defaultEnv)
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	envList, err := runner.BuildEnvList(ctx.Logger, e.Env(), inputEnv, defaultEnv)
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	targetDir, isTmp, err := waitSpec.Dir.ExpandDirectory(
+		ctx.Logger,
+		e.WorkspacePath(),
+		e.FlowFilePath(),
+		ctx.ProcessTmpDir,
+		envMap,
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to expand directory")
+	} else if isTmp {
+		ctx.ProcessTmpDir = targetDir
+	}
+
+	expand := func(value string) string {
+		return os.Expand(value, func(key string) string { return envMap[key] })
+	}
+	cond := &condition{
+		ctx:          ctx,
+		e:            e,
+		http:         expand(waitSpec.Http),
+		status:       waitSpec.Status,
+		tcp:          expand(waitSpec.Tcp),
+		file:         expand(waitSpec.File),
+		absent:       waitSpec.Absent,
+		cmd:          waitSpec.Cmd,
+		expr:         waitSpec.Expr,
+		dir:          targetDir,
+		env:          append(os.Environ(), envList...),
+		envMap:       envMap,
+		checkTimeout: waitSpec.Interval,
+	}
+	message := waitSpec.Message
+	if message == "" {
+		message = "Waiting until " + waitSpec.Condition()
+	}
+	return waitUntil(ctx, cond, message, &waitSpec)
+}
+
+// waitUntil checks the condition every interval until it is met or the timeout is reached.
+func waitUntil(ctx *context.Context, cond *condition, message string, w *executable.WaitExecutableType) error {
+	waitCtx, cancel := stdCtx.WithTimeout(ctx.Ctx, w.Timeout)
+	defer cancel()
+	progress := startProgress(ctx, message)
+	defer progress.stop()
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		lastErr := cond.check(waitCtx)
+		if lastErr == nil {
+			progress.stop()
+			ctx.Logger.Infof("%s (%s)", w.Condition(), progress.elapsed())
+			return nil
+		} else if errors.Is(lastErr, errInvalidCondition) {
+			return lastErr
+		}
+		ctx.Logger.Debugf("wait condition not met: %v", lastErr)
+		select {
+		case <-waitCtx.Done():
+			if errors.Is(waitCtx.Err(), stdCtx.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting until %s: %w", w.Timeout, w.Condition(), lastErr)
+			}
+			return waitCtx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package wait_test
+
+import (
+	stdCtx "context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/runner"
+	"github.com/jahvon/flow/internal/runner/wait"
+	"github.com/jahvon/flow/internal/services/store"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestWait(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Wait Runner Suite")
+}
+
+var _ = Describe("Wait Runner", func() {
+	var (
+		ctx     *context.Context
+		waitRnr runner.Runner
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContext(stdCtx.Background(), GinkgoT())
+		waitRnr = wait.NewRunner()
+	})
+
+	newExec := func(spec *executable.WaitExecutableType) *executable.Executable {
+		if spec.Interval == 0 {
+			spec.Interval = 20 * time.Millisecond
+		}
+		if spec.Timeout == 0 {
+			spec.Timeout = 2 * time.Second
+		}
+		e := &executable.Executable{Verb: "exec", Name: "wait", Wait: spec}
+		ws := ctx.CurrentWorkspace
+		e.SetContext(ws.AssignedName(), ws.Location(), "ns", ws.Location()+"/wait.flow")
+		return e
+	}
+
+	Context("IsCompatible", func() {
+		It("should return false when executable is nil", func() {
+			Expect(waitRnr.IsCompatible(nil)).To(BeFalse())
+		})
+
+		It("should return true when executable type is wait", func() {
+			Expect(waitRnr.IsCompatible(newExec(&executable.WaitExecutableType{Cmd: "true"}))).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		It("should wait for the file to exist", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "ready")
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				_ = os.WriteFile(path, nil, 0600)
+			}()
+			e := newExec(&executable.WaitExecutableType{File: path})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(path).To(BeAnExistingFile())
+		})
+
+		It("should wait for the file to be removed", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "lock")
+			Expect(os.WriteFile(path, nil, 0600)).To(Succeed())
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				_ = os.Remove(path)
+			}()
+			e := newExec(&executable.WaitExecutableType{File: path, Absent: true})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+		})
+
+		It("should wait for the address to accept connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer listener.Close()
+			e := newExec(&executable.WaitExecutableType{Tcp: listener.Addr().String()})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+		})
+
+		It("should wait for the URL to return the expected status", func() {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+			e := newExec(&executable.WaitExecutableType{Http: server.URL, Status: http.StatusNoContent})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+			Expect(requests.Load()).To(BeEquivalentTo(3))
+		})
+
+		It("should wait for the command to succeed with the executable's env", func() {
+			e := newExec(&executable.WaitExecutableType{
+				Cmd:    `test "$STATUS" = "done"`,
+				Params: executable.ParameterList{{EnvKey: "STATUS", Text: "done"}},
+			})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+		})
+
+		It("should read the store again before each check", func() {
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				s, err := store.NewBucketStore(store.EnvironmentBucket())
+				if err != nil {
+					return
+				}
+				defer s.Close()
+				_ = s.Set("deployed", "true")
+			}()
+			e := newExec(&executable.WaitExecutableType{Expr: `store["deployed"] == "true"`})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(Succeed())
+		})
+
+		It("should fail with the condition when the timeout is reached", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "missing")
+			e := newExec(&executable.WaitExecutableType{File: path, Timeout: 100 * time.Millisecond})
+			err := waitRnr.Exec(ctx, e, nil, make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("timed out after 100ms waiting until " + path + " exists")))
+		})
+
+		It("should fail immediately when the expression is invalid", func() {
+			e := newExec(&executable.WaitExecutableType{Expr: `store[`, Timeout: time.Minute})
+			Expect(waitRnr.Exec(ctx, e, nil, make(map[string]string))).To(MatchError(ContainSubstring("invalid")))
+		})
+	})
+})
@@ -0,0 +1,174 @@
+package runner
+
+import (
+	stdCtx "context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/internal/runner/engine"
+	"github.com/jahvon/flow/internal/services/watch"
+	"github.com/jahvon/flow/internal/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const (
+	clearScreen     = "\033[H\033[2J"
+	maxChangedFiles = 3
+)
+
+// ExecWatch runs the executable and re-runs it each time the files matching its watch configuration change.
+// Failed runs are logged instead of ending the watch, which continues until the context is cancelled.
+func ExecWatch(
+	ctx *context.Context,
+	e *executable.Executable,
+	eng engine.Engine,
+	inputEnv map[string]string,
+) error {
+	spec := e.Watch
+	if spec == nil {
+		spec = &executable.WatchConfig{}
+	}
+	watcher := watch.NewWatcher(ctx.Logger, watchOptions(ctx, e, spec))
+	if len(watcher.Files()) == 0 {
+		ctx.Logger.Warnf("no files match the watch paths of %s", e.Ref())
+	}
+
+	var succeeded, failed int
+	for run := 1; ; run++ {
+		if spec.Clear {
+			_, _ = fmt.Fprint(ctx.StdOut(), clearScreen)
+		}
+		ctx.Logger.PlainTextInfo(fmt.Sprintf("▶ run #%d of %s", run, e.Ref()))
+		watcher.Reset()
+
+		runCtx := *ctx
+		runCtx.Ctx, runCtx.CancelFunc = stdCtx.WithCancel(ctx.Ctx)
+		done := make(chan error, 1)
+		startTime := time.Now()
+		go func() {
+			done <- Exec(&runCtx, e, eng, inputEnv)
+		}()
+
+		var err error
+		var changed []string
+		if spec.Restart {
+			changed, err = waitForRunOrChange(ctx, &runCtx, watcher, done)
+			runCtx.CancelFunc()
+			if changed != nil {
+				logChangedFiles(ctx, changed)
+				continue
+			}
+		} else {
+			err = <-done
+			runCtx.CancelFunc()
+		}
+		if ctx.Ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			failed++
+			ctx.Logger.Errorf("run #%d failed after %s: %v", run, time.Since(startTime).Round(time.Millisecond), err)
+		} else {
+			succeeded++
+			ctx.Logger.PlainTextSuccess(fmt.Sprintf(
+				"✔ run #%d completed in %s", run, time.Since(startTime).Round(time.Millisecond),
+			))
+		}
+		ctx.Logger.PlainTextInfo(fmt.Sprintf(
+			"%d succeeded, %d failed; watching %d files for changes...", succeeded, failed, len(watcher.Files()),
+		))
+
+		changed, err = watcher.Wait(ctx.Ctx)
+		if errors.Is(err, stdCtx.Canceled) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		logChangedFiles(ctx, changed)
+	}
+}
+
+// waitForRunOrChange waits for the run to complete or for the watched files to change. If the files change first,
+// the run is stopped and the changed files are returned. Otherwise, the error of the run is returned.
+func waitForRunOrChange(
+	ctx *context.Context,
+	runCtx *context.Context,
+	watcher *watch.Watcher,
+	done chan error,
+) ([]string, error) {
+	waitCtx, cancel := stdCtx.WithCancel(ctx.Ctx)
+	defer cancel()
+	changes := make(chan []string, 1)
+	go func() {
+		changed, _ := watcher.Wait(waitCtx)
+		changes <- changed
+	}()
+
+	select {
+	case err := <-done:
+		cancel()
+		if changed := <-changes; changed != nil {
+			// The files changed as the run completed so the executable is re-run right away.
+			return changed, nil
+		}
+		return nil, err
+	case changed := <-changes:
+		runCtx.CancelFunc()
+		err := <-done
+		if changed != nil {
+			ctx.Logger.PlainTextInfo("■ stopped the current run")
+		}
+		return changed, err
+	}
+}
+
+func watchOptions(ctx *context.Context, e *executable.Executable, spec *executable.WatchConfig) watch.Options {
+	env := DefaultEnv(ctx, e)
+	expand := func(path string) string {
+		return utils.ExpandDirectory(ctx.Logger, path, e.WorkspacePath(), e.FlowFilePath(), env)
+	}
+
+	opts := watch.Options{
+		Debounce:      spec.Debounce,
+		WorkspacePath: e.WorkspacePath(),
+	}
+	for _, path := range spec.Paths {
+		opts.Paths = append(opts.Paths, expand(path))
+	}
+	if len(opts.Paths) == 0 {
+		opts.Paths = []string{filepath.Dir(e.FlowFilePath())}
+	}
+	for _, pattern := range spec.Ignore {
+		if strings.Contains(pattern, "/") {
+			pattern = expand(pattern)
+		}
+		opts.Ignore = append(opts.Ignore, pattern)
+	}
+	wsCfg, err := filesystem.LoadWorkspaceConfig(e.Workspace(), e.WorkspacePath())
+	if err != nil {
+		ctx.Logger.Warnx("unable to load workspace excluded paths", "workspace", e.Workspace(), "err", err)
+	} else if wsCfg.Executables != nil {
+		opts.Excluded = wsCfg.Executables.Excluded
+	}
+	return opts
+}
+
+func logChangedFiles(ctx *context.Context, changed []string) {
+	names := make([]string, 0, len(changed))
+	for _, path := range changed {
+		if rel, err := utils.PathFromWd(path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		names = append(names, path)
+	}
+	if len(names) > maxChangedFiles {
+		names = append(names[:maxChangedFiles], fmt.Sprintf("and %d more", len(changed)-maxChangedFiles))
+	}
+	ctx.Logger.PlainTextInfo(fmt.Sprintf("↻ changed: %s", strings.Join(names, ", ")))
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// logPollInterval is how often the log file of a run is read for new output while it is streamed.
+const logPollInterval = 250 * time.Millisecond
+
+// streamRunLogs sends the output of the run as server-sent events. Each line of output is sent as a `log` event and
+// a `status` event with the run is sent once the run has finished and all of its output has been sent.
+func (s *Server) streamRunLogs(w http.ResponseWriter, r *http.Request) {
+	run, found := s.runs.get(r.PathValue("id"))
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("run %s not found", r.PathValue("id")))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	file, err := os.Open(run.logFile)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("output of run %s not found", run.ID))
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	reader := bufio.NewReader(file)
+	var partial string
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	for {
+		// The run is checked before reading so that the output written before it finished is always sent.
+		var finished bool
+		select {
+		case <-run.Done():
+			finished = true
+		default:
+		}
+		for {
+			line, err := reader.ReadString('\n')
+			if errors.Is(err, io.EOF) {
+				// An incomplete line is kept until the rest of it is written.
+				partial += line
+				break
+			} else if err != nil {
+				return
+			}
+			writeEvent(w, "log", partial+line[:len(line)-1])
+			partial = ""
+		}
+		if finished {
+			if partial != "" {
+				writeEvent(w, "log", partial)
+			}
+			final, _ := s.runs.get(run.ID)
+			data, _ := json.Marshal(final)
+			writeEvent(w, "status", string(data))
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-run.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event, data string) {
+	_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "running"
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+// maxRuns is the number of finished runs that are kept. The log files of older runs are removed.
+const maxRuns = 100
+
+// Run is an execution that was started through the API.
+type Run struct {
+	ID        string     `json:"id"`
+	Ref       string     `json:"ref"`
+	Args      []string   `json:"args,omitempty"`
+	Status    RunStatus  `json:"status"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+	Error     string     `json:"error,omitempty"`
+
+	logFile string
+	done    chan struct{}
+}
+
+// Done returns a channel that is closed when the run has finished.
+func (r *Run) Done() <-chan struct{} {
+	return r.done
+}
+
+// runList keeps the runs in the order they were started.
+type runList struct {
+	dir  string
+	mu   sync.RWMutex
+	runs []*Run
+}
+
+func (l *runList) start(ref string, args []string) (*Run, *os.File, error) {
+	idBytes := make([]byte, 6)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, nil, err
+	}
+	run := &Run{
+		ID:        hex.EncodeToString(idBytes),
+		Ref:       ref,
+		Args:      args,
+		Status:    RunStatusRunning,
+		StartedAt: time.Now(),
+		done:      make(chan struct{}),
+	}
+	run.logFile = filepath.Join(l.dir, run.ID+".log")
+	logFile, err := os.OpenFile(run.logFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.runs = append(l.runs, run)
+	l.prune()
+	return run, logFile, nil
+}
+
+func (l *runList) finish(run *Run, runErr error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	run.EndedAt = &now
+	if runErr != nil {
+		run.Status = RunStatusFailed
+		run.Error = runErr.Error()
+	} else {
+		run.Status = RunStatusSucceeded
+	}
+	close(run.done)
+}
+
+// prune removes the oldest finished runs when there are more than maxRuns. The caller must hold the lock.
+func (l *runList) prune() {
+	for len(l.runs) > maxRuns {
+		i := slices.IndexFunc(l.runs, func(r *Run) bool { return r.Status != RunStatusRunning })
+		if i < 0 {
+			return
+		}
+		_ = os.Remove(l.runs[i].logFile)
+		l.runs = slices.Delete(l.runs, i, i+1)
+	}
+}
+
+func (l *runList) get(id string) (Run, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, run := range l.runs {
+		if run.ID == id {
+			return *run, true
+		}
+	}
+	return Run{}, false
+}
+
+func (l *runList) list() []Run {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	runs := make([]Run, 0, len(l.runs))
+	for _, run := range l.runs {
+		runs = append(runs, *run)
+	}
+	return runs
+}
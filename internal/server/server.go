@@ -0,0 +1,279 @@
+package server
+
+import (
+	stdCtx "context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/types/common"
+	"github.com/jahvon/flow/types/config"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// RunFunc runs an executable with the arguments given in the request and writes its output to out. The run should
+// stop when the context is cancelled.
+type RunFunc func(ctx stdCtx.Context, e *executable.Executable, args []string, out stdio.Writer) error
+
+type Options struct {
+	// Token is the bearer token that every request must include.
+	Token string
+	// Allowed determines which executables can be run. If nil, no executables can be run.
+	Allowed *config.ServerConfig
+	// RunsDir is the directory that the output of the runs is saved in.
+	RunsDir string
+	Run     RunFunc
+}
+
+// Server is the REST API for listing and running executables.
+type Server struct {
+	ctx  *context.Context
+	opts Options
+	runs *runList
+	wg   sync.WaitGroup
+
+	runCtx     stdCtx.Context
+	cancelRuns stdCtx.CancelFunc
+}
+
+func New(ctx *context.Context, opts Options) *Server {
+	runCtx, cancelRuns := stdCtx.WithCancel(ctx.Ctx)
+	return &Server{
+		ctx:        ctx,
+		opts:       opts,
+		runs:       &runList{dir: opts.RunsDir},
+		runCtx:     runCtx,
+		cancelRuns: cancelRuns,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workspaces", s.listWorkspaces)
+	mux.HandleFunc("GET /api/v1/executables", s.listExecutables)
+	mux.HandleFunc("GET /api/v1/executables/{verb}/{id...}", s.getExecutable)
+	mux.HandleFunc("POST /api/v1/runs", s.startRun)
+	mux.HandleFunc("GET /api/v1/runs", s.listRuns)
+	mux.HandleFunc("GET /api/v1/runs/{id}", s.getRun)
+	mux.HandleFunc("GET /api/v1/runs/{id}/logs", s.streamRunLogs)
+	return s.authenticate(mux)
+}
+
+// Wait blocks until the runs that are in progress have finished.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown cancels the runs that are in progress and waits for them to stop.
+func (s *Server) Shutdown() {
+	s.cancelRuns()
+	s.wg.Wait()
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "a valid bearer token is required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allowed returns true if the executable can be run through the API.
+func (s *Server) Allowed(e *executable.Executable) bool {
+	allowed := s.opts.Allowed
+	if allowed == nil {
+		return false
+	}
+	visibility := common.VisibilityPrivate
+	if e.Visibility != nil {
+		visibility = common.Visibility(*e.Visibility)
+	}
+	if visibility == common.VisibilityHidden {
+		return false
+	}
+	for _, a := range allowed.AllowedExecutables {
+		if a == e.ID() || executable.Ref(a).Equals(e.Ref()) {
+			return true
+		}
+	}
+	return slices.Contains(allowed.AllowedVisibilities, config.ServerConfigAllowedVisibilitiesElem(visibility))
+}
+
+func (s *Server) listWorkspaces(w http.ResponseWriter, _ *http.Request) {
+	workspaces, err := s.ctx.WorkspacesCache.GetWorkspaceConfigList(s.ctx.Logger)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	data, err := workspaces.JSON()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRaw(w, http.StatusOK, data)
+}
+
+func (s *Server) listExecutables(w http.ResponseWriter, r *http.Request) {
+	execs, err := s.ctx.ExecutableCache.GetExecutableList(s.ctx.Logger)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	if ws := query.Get("workspace"); ws != "" {
+		execs = execs.FilterByWorkspace(ws)
+	}
+	if ns := query.Get("namespace"); ns != "" {
+		execs = execs.FilterByNamespace(ns)
+	}
+	if verb := query.Get("verb"); verb != "" {
+		execs = execs.FilterByVerb(executable.Verb(verb))
+	}
+	if tags := query["tag"]; len(tags) > 0 {
+		execs = execs.FilterByTags(tags)
+	}
+	if filter := query.Get("filter"); filter != "" {
+		execs = execs.FilterBySubstring(filter)
+	}
+	data, err := execs.JSON()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRaw(w, http.StatusOK, data)
+}
+
+type executableResponse struct {
+	ID       string                 `json:"id"`
+	Ref      string                 `json:"ref"`
+	Allowed  bool                   `json:"allowed"`
+	Markdown string                 `json:"markdown"`
+	Spec     *executable.Executable `json:"spec"`
+}
+
+func (s *Server) getExecutable(w http.ResponseWriter, r *http.Request) {
+	ref := executable.NewRef(r.PathValue("id"), executable.Verb(r.PathValue("verb")))
+	e, status, err := s.findExecutable(ref)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, executableResponse{
+		ID:       e.ID(),
+		Ref:      e.Ref().String(),
+		Allowed:  s.Allowed(e),
+		Markdown: e.Markdown(),
+		Spec:     e,
+	})
+}
+
+// CommandArgs returns the flow command line arguments that run the executable with the arguments of a run request.
+// The request's arguments are given after `--` so that they are passed to the executable instead of being parsed as
+// flow flags.
+func CommandArgs(e *executable.Executable, args []string) []string {
+	return append([]string{e.Verb.String(), e.ID(), "--"}, args...)
+}
+
+type runRequest struct {
+	Ref  string   `json:"ref"`
+	Args []string `json:"args"`
+}
+
+func (s *Server) startRun(w http.ResponseWriter, r *http.Request) {
+	var req runRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	verb, id, found := strings.Cut(req.Ref, " ")
+	if !found {
+		writeError(w, http.StatusBadRequest, "ref must be an executable reference (e.g. `deploy ws/ns:app`)")
+		return
+	}
+	e, status, err := s.findExecutable(executable.NewRef(id, executable.Verb(verb)))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	if !s.Allowed(e) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("%s is not allowed to be run through the API", e.Ref()))
+		return
+	}
+	run, logFile, err := s.runs.start(e.Ref().String(), req.Args)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.ctx.Logger.Infof("starting run %s of %s", run.ID, run.Ref)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		runErr := s.opts.Run(s.runCtx, e, req.Args, logFile)
+		if runErr != nil {
+			_, _ = fmt.Fprintf(logFile, "\nrun failed: %v\n", runErr)
+			s.ctx.Logger.Errorf("run %s of %s failed: %v", run.ID, run.Ref, runErr)
+		} else {
+			s.ctx.Logger.Infof("run %s of %s succeeded", run.ID, run.Ref)
+		}
+		_ = logFile.Close()
+		s.runs.finish(run, runErr)
+	}()
+
+	started, _ := s.runs.get(run.ID)
+	writeJSON(w, http.StatusAccepted, started)
+}
+
+func (s *Server) listRuns(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]Run{"runs": s.runs.list()})
+}
+
+func (s *Server) getRun(w http.ResponseWriter, r *http.Request) {
+	run, found := s.runs.get(r.PathValue("id"))
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("run %s not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+// findExecutable returns the executable and the status code to respond with if it cannot be found.
+func (s *Server) findExecutable(ref executable.Ref) (*executable.Executable, int, error) {
+	if err := ref.Verb().Validate(); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	e, err := s.ctx.ExecutableCache.GetExecutableByRef(s.ctx.Logger, context.ExpandRef(s.ctx, ref))
+	if err != nil || e == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("executable %s not found", ref)
+	}
+	return e, http.StatusOK, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRaw(w, status, string(data))
+}
+
+func writeRaw(w http.ResponseWriter, status int, data string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintln(w, data)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	data, _ := json.Marshal(map[string]string{"error": msg})
+	writeRaw(w, status, string(data))
+}
@@ -0,0 +1,219 @@
+package server_test
+
+import (
+	stdCtx "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/server"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/common"
+	"github.com/jahvon/flow/types/config"
+	"github.com/jahvon/flow/types/executable"
+)
+
+const token = "test-token"
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Server Suite")
+}
+
+var _ = Describe("Server", func() {
+	var (
+		ctx     *testUtils.ContextWithMocks
+		srv     *server.Server
+		ts      *httptest.Server
+		execs   executable.ExecutableList
+		deploy  *executable.Executable
+		cleanup *executable.Executable
+	)
+
+	newExec := func(verb executable.Verb, name, ns string, visibility common.Visibility) *executable.Executable {
+		v := executable.ExecutableVisibility(visibility)
+		e := &executable.Executable{
+			Verb:        verb,
+			Name:        name,
+			Description: "The " + name + " executable",
+			Visibility:  &v,
+			Exec:        &executable.ExecExecutableType{Cmd: "echo " + name},
+		}
+		e.SetContext("ws", "/ws", ns, "/ws/"+ns+".flow")
+		return e
+	}
+
+	do := func(method, path, body string) *http.Response {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	decode := func(resp *http.Response, v any) {
+		defer resp.Body.Close()
+		Expect(json.NewDecoder(resp.Body).Decode(v)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+		ctx.Logger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+		deploy = newExec("deploy", "app", "apps", common.VisibilityPrivate)
+		cleanup = newExec("remove", "cache", "ops", common.VisibilityPublic)
+		execs = executable.ExecutableList{deploy, cleanup}
+		ctx.ExecutableCache.EXPECT().GetExecutableList(gomock.Any()).Return(execs, nil).AnyTimes()
+		ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, ref executable.Ref) (*executable.Executable, error) {
+				for _, e := range execs {
+					if e.Ref().Equals(ref) {
+						return e, nil
+					}
+				}
+				return nil, fmt.Errorf("%s not found", ref)
+			}).AnyTimes()
+
+		run := func(runCtx stdCtx.Context, e *executable.Executable, args []string, out io.Writer) error {
+			_, _ = fmt.Fprintf(out, "running %s with %s\n", e.Ref(), strings.Join(args, ","))
+			if len(args) > 0 {
+				switch args[0] {
+				case "fail":
+					return fmt.Errorf("failed on purpose")
+				case "block":
+					<-runCtx.Done()
+					return runCtx.Err()
+				}
+			}
+			_, _ = fmt.Fprintln(out, "done")
+			return nil
+		}
+		srv = server.New(ctx.Ctx, server.Options{
+			Token:   token,
+			Allowed: &config.ServerConfig{AllowedExecutables: []string{"deploy ws/apps:app"}},
+			RunsDir: GinkgoT().TempDir(),
+			Run:     run,
+		})
+		ts = httptest.NewServer(srv.Handler())
+		DeferCleanup(func() {
+			ts.Close()
+			srv.Wait()
+		})
+	})
+
+	It("should reject requests without the token", func() {
+		resp, err := http.Get(ts.URL + "/api/v1/executables")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should list the executables that match the filters", func() {
+		resp := do(http.MethodGet, "/api/v1/executables?namespace=ops", "")
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var body struct {
+			Executables []struct {
+				ID string `json:"id"`
+			} `json:"executables"`
+		}
+		decode(resp, &body)
+		Expect(body.Executables).To(HaveLen(1))
+		Expect(body.Executables[0].ID).To(Equal("ws/ops:cache"))
+	})
+
+	It("should return the executable's documentation", func() {
+		resp := do(http.MethodGet, "/api/v1/executables/deploy/ws/apps:app", "")
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var body map[string]any
+		decode(resp, &body)
+		Expect(body["ref"]).To(Equal("deploy ws/apps:app"))
+		Expect(body["allowed"]).To(BeTrue())
+		Expect(body["markdown"]).To(ContainSubstring("The app executable"))
+	})
+
+	It("should not run executables that are not allowed", func() {
+		resp := do(http.MethodPost, "/api/v1/runs", `{"ref": "remove ws/ops:cache"}`)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+	})
+
+	It("should run the executable and stream its output", func() {
+		resp := do(http.MethodPost, "/api/v1/runs", `{"ref": "deploy ws/apps:app", "args": ["v1"]}`)
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		var run server.Run
+		decode(resp, &run)
+		Expect(run.Ref).To(Equal("deploy ws/apps:app"))
+
+		resp = do(http.MethodGet, "/api/v1/runs/"+run.ID+"/logs", "")
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+		stream, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		_ = resp.Body.Close()
+		Expect(string(stream)).To(ContainSubstring("event: log\ndata: running deploy ws/apps:app with v1\n\n"))
+		Expect(string(stream)).To(ContainSubstring("event: log\ndata: done\n\n"))
+		Expect(string(stream)).To(MatchRegexp(`event: status\ndata: \{.*"status":"succeeded"`))
+
+		resp = do(http.MethodGet, "/api/v1/runs/"+run.ID, "")
+		decode(resp, &run)
+		Expect(run.Status).To(Equal(server.RunStatusSucceeded))
+		Expect(run.Args).To(Equal([]string{"v1"}))
+	})
+
+	It("should not parse the run arguments as flow flags", func() {
+		resp := do(http.MethodPost, "/api/v1/runs", `{"ref": "deploy ws/apps:app", "args": ["--yes", "v1"]}`)
+		var run server.Run
+		decode(resp, &run)
+		srv.Wait()
+		Expect(run.Args).To(Equal([]string{"--yes", "v1"}))
+
+		var execArgs []string
+		cmd := &cobra.Command{
+			Use: "deploy",
+			Run: func(_ *cobra.Command, args []string) { execArgs = args },
+		}
+		yes := cmd.Flags().Bool("yes", false, "")
+		cmd.SetArgs(server.CommandArgs(deploy, run.Args)[1:])
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(*yes).To(BeFalse())
+		Expect(execArgs).To(Equal([]string{"ws/apps:app", "--yes", "v1"}))
+	})
+
+	It("should report the error of a failed run", func() {
+		resp := do(http.MethodPost, "/api/v1/runs", `{"ref": "deploy ws/apps:app", "args": ["fail"]}`)
+		var run server.Run
+		decode(resp, &run)
+		srv.Wait()
+
+		resp = do(http.MethodGet, "/api/v1/runs", "")
+		var body struct {
+			Runs []server.Run `json:"runs"`
+		}
+		decode(resp, &body)
+		Expect(body.Runs).To(HaveLen(1))
+		Expect(body.Runs[0].Status).To(Equal(server.RunStatusFailed))
+		Expect(body.Runs[0].Error).To(Equal("failed on purpose"))
+	})
+
+	It("should cancel the runs in progress on shutdown", func() {
+		resp := do(http.MethodPost, "/api/v1/runs", `{"ref": "deploy ws/apps:app", "args": ["block"]}`)
+		var run server.Run
+		decode(resp, &run)
+		Expect(run.Status).To(Equal(server.RunStatusRunning))
+
+		srv.Shutdown()
+		resp = do(http.MethodGet, "/api/v1/runs/"+run.ID, "")
+		decode(resp, &run)
+		Expect(run.Status).To(Equal(server.RunStatusFailed))
+		Expect(run.Error).To(Equal(stdCtx.Canceled.Error()))
+	})
+})
@@ -0,0 +1,179 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/run"
+	"github.com/jahvon/flow/internal/services/store"
+)
+
+// BucketName is the name of the store bucket that the records of the started services are saved in.
+const BucketName = "services"
+
+// Record is a service that was started in the background.
+type Record struct {
+	Ref         string        `json:"ref" yaml:"ref"`
+	PID         int           `json:"pid" yaml:"pid"`
+	Cmd         string        `json:"cmd" yaml:"cmd"`
+	Dir         string        `json:"dir" yaml:"dir"`
+	LogFile     string        `json:"logFile" yaml:"logFile"`
+	StartedAt   time.Time     `json:"startedAt" yaml:"startedAt"`
+	StopTimeout time.Duration `json:"stopTimeout" yaml:"stopTimeout"`
+	// Owner is the pid of the flow process that started the service.
+	Owner int `json:"owner" yaml:"owner"`
+}
+
+// Running returns true if the service's process is still running.
+func (r *Record) Running() bool {
+	return processRunning(r.PID)
+}
+
+type StartOptions struct {
+	Ref         string
+	Cmd         string
+	Dir         string
+	Env         []string
+	LogFile     string
+	StopTimeout time.Duration
+}
+
+// Start runs the command in a new flow process with its output written to the log file, and saves its record.
+// The process is started with the arguments returned by ServiceCmdArgs. The returned channel receives the result of
+// the process when it exits.
+func Start(opts StartOptions) (*Record, <-chan error, error) {
+	logFile, err := os.OpenFile(opts.LogFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to create service log file")
+	}
+	self, err := os.Executable()
+	if err != nil {
+		_ = logFile.Close()
+		return nil, nil, errors.Wrap(err, "unable to find the flow executable")
+	}
+	env := opts.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd := exec.Command(self, ServiceCmdArgs(opts.Cmd)...)
+	cmd.Dir = opts.Dir
+	cmd.Env = env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		_ = logFile.Close()
+		return nil, nil, errors.Wrap(err, "unable to start service")
+	}
+	_ = logFile.Close()
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	record := &Record{
+		Ref:         opts.Ref,
+		PID:         cmd.Process.Pid,
+		Cmd:         opts.Cmd,
+		Dir:         opts.Dir,
+		LogFile:     opts.LogFile,
+		StartedAt:   time.Now(),
+		StopTimeout: opts.StopTimeout,
+		Owner:       os.Getpid(),
+	}
+	if err := Save(record); err != nil {
+		_ = stopProcess(record.PID, 0)
+		return nil, nil, err
+	}
+	return record, exited, nil
+}
+
+// Stop asks the service to exit, kills it if it has not exited after its stop timeout, and removes its record.
+func Stop(record *Record) error {
+	if record.Running() {
+		if err := stopProcess(record.PID, record.StopTimeout); err != nil {
+			return errors.Wrapf(err, "unable to stop %s", record.Ref)
+		}
+	}
+	return Remove(record.Ref)
+}
+
+// ServiceCmdArgs returns the arguments of the hidden flow command that runs the service's command.
+func ServiceCmdArgs(command string) []string {
+	return []string{"service", "run-cmd", "--", command}
+}
+
+// RunCmd runs the service's command in the current process with its environment and standard IO, so that the
+// command is run by the same shell interpreter as other executables.
+func RunCmd(ctx context.Context, command string) error {
+	return run.RunCmdWithIO(ctx, command, "", os.Environ(), os.Stdin, os.Stdout, os.Stderr)
+}
+
+func Save(record *Record) error {
+	s, err := store.NewBucketStore(BucketName)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.Set(record.Ref, string(data))
+}
+
+// Get returns the record of the service. Nil is returned if the service has not been started.
+func Get(ref string) (*Record, error) {
+	records, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.Ref == ref {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+// List returns the records of the started services, sorted by reference.
+func List() ([]*Record, error) {
+	s, err := store.NewBucketStore(BucketName)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	data, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Record, 0, len(data))
+	for ref, val := range data {
+		record := &Record{}
+		if err := json.Unmarshal([]byte(val), record); err != nil {
+			return nil, fmt.Errorf("unable to read record of %s: %w", ref, err)
+		}
+		records = append(records, record)
+	}
+	slices.SortFunc(records, func(a, b *Record) int {
+		return strings.Compare(a.Ref, b.Ref)
+	})
+	return records, nil
+}
+
+func Remove(ref string) error {
+	s, err := store.NewBucketStore(BucketName)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return s.Delete(ref)
+}
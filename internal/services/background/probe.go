@@ -0,0 +1,94 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/check"
+)
+
+// Probe checks whether a service is ready. Only one of HTTP, TCP, Log, or Cmd is checked.
+type Probe struct {
+	HTTP     string
+	Status   int
+	TCP      string
+	Log      *regexp.Regexp
+	Cmd      string
+	Dir      string
+	Env      []string
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// WaitReady checks the probe until it succeeds. An error is returned if the timeout is reached, the context is
+// cancelled, or the service exits. The exited channel is nil if the service was not started by this process.
+func WaitReady(ctx context.Context, probe Probe, record *Record, exited <-chan error) error {
+	ctx, cancel := context.WithTimeout(ctx, probe.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(probe.Interval)
+	defer ticker.Stop()
+	for {
+		if probe.ready(ctx, record) {
+			return nil
+		}
+		if exited == nil && !record.Running() {
+			return errors.New("service exited before it was ready")
+		}
+		select {
+		case err := <-exited:
+			if err != nil {
+				return fmt.Errorf("service exited before it was ready: %w", err)
+			}
+			return errors.New("service exited before it was ready")
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("service was not ready after %s", probe.Timeout)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p Probe) ready(ctx context.Context, record *Record) bool {
+	switch {
+	case p.HTTP != "":
+		return check.HTTP(ctx, p.HTTP, p.Status, p.Interval) == nil
+	case p.TCP != "":
+		return check.TCP(ctx, p.TCP, p.Interval) == nil
+	case p.Log != nil:
+		data, err := os.ReadFile(record.LogFile)
+		if err != nil {
+			return false
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if p.Log.MatchString(line) {
+				return true
+			}
+		}
+		return false
+	case p.Cmd != "":
+		return check.Cmd(ctx, p.Cmd, p.Dir, p.Env) == nil
+	default:
+		return true
+	}
+}
+
+// LogTail returns the last lines of the service's log file.
+func LogTail(record *Record, lines int) string {
+	data, err := os.ReadFile(record.LogFile)
+	if err != nil {
+		return ""
+	}
+	all := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n")
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package background
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// setProcessGroup starts the command in a new process group so that it is not sent the signals of flow's console.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+func processRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
+
+// stopProcess kills the process. Windows processes cannot be asked to exit, so the timeout is not used.
+func stopProcess(pid int, _ time.Duration) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return nil
+	}
+	return p.Kill()
+}
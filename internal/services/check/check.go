@@ -0,0 +1,55 @@
+// Package check has the checks that are shared by service readiness probes and wait conditions.
+package check
+
+import (
+	"context"
+	"fmt"
+	stdio "io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jahvon/flow/internal/services/run"
+)
+
+// ErrInvalidRequest is returned by HTTP when a request cannot be created for the URL.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// HTTP returns nil when a GET request to the URL responds with the status. The timeout limits how long the request
+// can take.
+func HTTP(ctx context.Context, url string, status int, timeout time.Duration) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != status {
+		return fmt.Errorf("received status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TCP returns nil when a connection to the address can be opened. The timeout limits how long the connection
+// attempt can take.
+func TCP(ctx context.Context, address string, timeout time.Duration) error {
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	_ = conn.Close()
+	return nil
+}
+
+// Cmd returns nil when the command exits with a zero status. The command is run by the shell interpreter with the
+// environment in the directory, and its output is discarded.
+func Cmd(ctx context.Context, command, dir string, env []string) error {
+	return run.RunCmdWithIO(ctx, command, dir, env, nil, stdio.Discard, stdio.Discard)
+}
@@ -0,0 +1,61 @@
+package check_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/services/check"
+)
+
+func TestCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Check Suite")
+}
+
+var _ = Describe("Check", func() {
+	ctx := context.Background()
+
+	Describe("HTTP", func() {
+		It("should only succeed when the response has the status", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			Expect(check.HTTP(ctx, server.URL, http.StatusAccepted, time.Second)).To(Succeed())
+			Expect(check.HTTP(ctx, server.URL, http.StatusOK, time.Second)).To(MatchError("received status 202"))
+		})
+
+		It("should return an invalid request error for an invalid URL", func() {
+			err := check.HTTP(ctx, "http://[::1", http.StatusOK, time.Second)
+			Expect(err).To(MatchError(check.ErrInvalidRequest))
+		})
+	})
+
+	Describe("TCP", func() {
+		It("should succeed when a connection can be opened", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			address := listener.Addr().String()
+			Expect(check.TCP(ctx, address, time.Second)).To(Succeed())
+
+			Expect(listener.Close()).To(Succeed())
+			Expect(check.TCP(ctx, address, time.Second)).NotTo(Succeed())
+		})
+	})
+
+	Describe("Cmd", func() {
+		It("should succeed when the command exits with a zero status", func() {
+			dir := GinkgoT().TempDir()
+			Expect(check.Cmd(ctx, `test "$(pwd)" = "$DIR"`, dir, []string{"DIR=" + dir})).To(Succeed())
+			Expect(check.Cmd(ctx, "exit 3", dir, nil)).To(MatchError(ContainSubstring("non-zero status 3")))
+		})
+	})
+})
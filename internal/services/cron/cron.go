@@ -0,0 +1,196 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits how far ahead Next searches for a matching time so that schedules that can never match
+// (e.g. February 30th) do not loop forever.
+const maxSearchYears = 5
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr    string
+	minutes uint64
+	hours   uint64
+	days    uint64
+	months  uint64
+	weekday uint64
+	// anyDay and anyWeekday are set when the field starts with `*`. When both day fields are restricted,
+	// a time matches if either of them match.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// Parse parses a standard 5-field cron expression (minute, hour, day of month, month, day of week) or one of the
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight, and @hourly descriptors. Fields support `*`, lists,
+// ranges, steps, and month and day names.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		val, found := descriptors[strings.ToLower(spec)]
+		if !found {
+			return nil, fmt.Errorf("unknown cron descriptor %s", spec)
+		}
+		spec = val
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields, got %d", expr, len(fields), len(parts))
+	}
+
+	s := &Schedule{expr: expr}
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+	s.minutes, s.hours, s.days, s.months, s.weekday = bits[0], bits[1], bits[2], bits[3], bits[4]
+	// Sunday can be set as either 0 or 7.
+	if s.weekday&(1<<7) != 0 {
+		s.weekday |= 1
+	}
+	s.anyDay = strings.HasPrefix(parts[2], "*")
+	s.anyWeekday = strings.HasPrefix(parts[4], "*")
+	return s, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rangeStr, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %s", f.name, stepStr)
+			}
+			step = n
+		}
+
+		var start, end int
+		switch {
+		case rangeStr == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rangeStr, "-"):
+			startStr, endStr, _ := strings.Cut(rangeStr, "-")
+			var err error
+			if start, err = parseValue(startStr, f); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(endStr, f); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid %s range %s", f.name, rangeStr)
+			}
+		default:
+			n, err := parseValue(rangeStr, f)
+			if err != nil {
+				return 0, err
+			}
+			start, end = n, n
+			if hasStep {
+				end = f.max
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	if n, found := f.names[strings.ToLower(value)]; found {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %s", f.name, value)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s value %d is out of range [%d-%d]", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// String returns the expression that the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after t that matches the schedule, in the location of t. A zero time is returned if
+// there is no matching time in the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekday&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
@@ -0,0 +1,54 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jahvon/flow/internal/services/cron"
+)
+
+func TestCron(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cron Suite")
+}
+
+var _ = Describe("Schedule", func() {
+	// Wednesday, January 15th 2025
+	start := time.Date(2025, time.January, 15, 10, 30, 15, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(expr string, expected time.Time) {
+			s, err := cron.Parse(expr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Next(start)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("minute step", "*/15 * * * *", time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)),
+		Entry("daily at a time", "0 9 * * *", time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC)),
+		Entry("weekdays", "0 9 * * mon-fri", time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 9 * * 7", time.Date(2025, time.January, 19, 9, 0, 0, 0, time.UTC)),
+		Entry("list of hours", "30 8,12,18 * * *", time.Date(2025, time.January, 15, 12, 30, 0, 0, time.UTC)),
+		Entry("month names", "0 0 1 mar *", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or weekday", "0 0 20 * fri", time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("hourly descriptor", "@hourly", time.Date(2025, time.January, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("monthly descriptor", "@monthly", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 feb *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("impossible date", "0 0 30 feb *", time.Time{}),
+	)
+
+	DescribeTable("Parse errors",
+		func(expr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("reversed range", "0 10-5 * * *"),
+		Entry("unknown name", "0 0 * * someday"),
+		Entry("unknown descriptor", "@sometimes"),
+	)
+})
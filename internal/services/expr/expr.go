@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 
 	"github.com/jahvon/flow/internal/context"
 	"github.com/jahvon/flow/types/executable"
 )
 
-func IsTruthy(ex string, env *ExpressionData) (bool, error) {
+func IsTruthy(ex string, env interface{}) (bool, error) {
 	output, err := Evaluate(ex, env)
 	if err != nil {
 		return false, err
@@ -35,7 +39,7 @@ func IsTruthy(ex string, env *ExpressionData) (bool, error) {
 	}
 }
 
-func Evaluate(ex string, env *ExpressionData) (interface{}, error) {
+func Evaluate(ex string, env interface{}) (interface{}, error) {
 	program, err := expr.Compile(ex, expr.Env(env))
 	if err != nil {
 		return nil, err
@@ -48,7 +52,13 @@ func Evaluate(ex string, env *ExpressionData) (interface{}, error) {
 	return output, nil
 }
 
-func EvaluateString(ex string, env *ExpressionData) (string, error) {
+// Check compiles the expression against the data that is available to executable expressions without evaluating it.
+func Check(ex string) error {
+	_, err := expr.Compile(ex, expr.Env(&ExpressionData{}))
+	return err
+}
+
+func EvaluateString(ex string, env interface{}) (string, error) {
 	output, err := Evaluate(ex, env)
 	if err != nil {
 		return "", err
@@ -60,6 +70,72 @@ func EvaluateString(ex string, env *ExpressionData) (string, error) {
 	return str, nil
 }
 
+// Variables returns the variables and member accesses referenced in the expression (e.g. `body.items`).
+// Only the longest access chain is returned for each reference.
+func Variables(ex string) ([]string, error) {
+	tree, err := parser.Parse(ex)
+	if err != nil {
+		return nil, err
+	}
+	v := &variableVisitor{}
+	ast.Walk(&tree.Node, v)
+
+	vars := make([]string, 0, len(v.vars))
+	for _, name := range v.vars {
+		if slices.Contains(vars, name) {
+			continue
+		}
+		isPrefix := slices.ContainsFunc(v.vars, func(other string) bool {
+			return strings.HasPrefix(other, name+".") || strings.HasPrefix(other, name+"[")
+		})
+		if !isPrefix {
+			vars = append(vars, name)
+		}
+	}
+	return vars, nil
+}
+
+type variableVisitor struct {
+	vars []string
+}
+
+func (v *variableVisitor) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode, *ast.MemberNode:
+		if ref, ok := referenceString(n); ok {
+			v.vars = append(v.vars, ref)
+		}
+	}
+}
+
+// referenceString formats an identifier or member access chain so that it can be evaluated on its own.
+// Map keys that are not valid identifiers are kept in bracket notation.
+func referenceString(node ast.Node) (string, bool) {
+	switch n := node.(type) {
+	case *ast.IdentifierNode:
+		return n.Value, true
+	case *ast.MemberNode:
+		base, ok := referenceString(n.Node)
+		if !ok {
+			return "", false
+		}
+		if prop, isStr := n.Property.(*ast.StringNode); isStr && isIdentifier(prop.Value) {
+			return base + "." + prop.Value, true
+		}
+		return fmt.Sprintf("%s[%s]", base, n.Property.String()), true
+	}
+	return "", false
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
 type CtxData struct {
 	Workspace     string `expr:"workspace"`
 	Namespace     string `expr:"namespace"`
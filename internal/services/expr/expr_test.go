@@ -132,4 +132,23 @@ var _ = Describe("Expr", func() {
 			})
 		})
 	})
+
+	Describe("Variables", func() {
+		It("should return the longest reference chains in the expression", func() {
+			vars, err := expr.Variables(`status == 200 && len(body.items) > 0 && body.items[0].id != env["ID"]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(ConsistOf("status", "body.items[0].id", "env.ID"))
+		})
+
+		It("should keep bracket notation for keys that are not identifiers", func() {
+			vars, err := expr.Variables(`headers["X-Request-Id"] == "abc"`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(ConsistOf(`headers["X-Request-Id"]`))
+		})
+
+		It("should return an error for invalid expressions", func() {
+			_, err := expr.Variables("status ==")
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
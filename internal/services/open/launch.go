@@ -0,0 +1,125 @@
+package open
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jahvon/open-golang/open"
+	"github.com/pkg/errors"
+)
+
+// LaunchOptions configure how a URI is launched.
+type LaunchOptions struct {
+	// App is the application to launch the URI with. The OS default is used when empty.
+	App string
+	// Args are passed to the application before the URI.
+	Args []string
+	// Env is a list of KEY=VALUE pairs added to the environment of the launched application.
+	Env []string
+	// Wait blocks until the application exits and returns an error if it exits with a non-zero status.
+	Wait bool
+}
+
+// Launch opens the URI with the configured application.
+func Launch(uri string, opts LaunchOptions) error {
+	cmd := Command(uri, opts)
+	if len(opts.Env) > 0 {
+		cmd.Env = append(os.Environ(), opts.Env...)
+	}
+
+	if !opts.Wait {
+		if err := cmd.Start(); err != nil {
+			return errors.Wrapf(err, "unable to launch %s", launchName(opts))
+		}
+		return cmd.Process.Release()
+	}
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s exited with status %d", launchName(opts), exitErr.ExitCode())
+		}
+		return errors.Wrapf(err, "unable to launch %s", launchName(opts))
+	}
+	return nil
+}
+
+// Command returns the command used to launch the URI on the current OS.
+func Command(uri string, opts LaunchOptions) *exec.Cmd {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name = "open"
+		if os.Getenv(open.OpenInBackgroundEnvKey) != "" {
+			args = append(args, "-g")
+		}
+		if opts.Wait {
+			args = append(args, "-W")
+		}
+		if opts.App != "" {
+			args = append(args, "-a", opts.App)
+		}
+		args = append(args, uri)
+		if len(opts.Args) > 0 {
+			args = append(args, "--args")
+			args = append(args, opts.Args...)
+		}
+	case "windows":
+		if opts.App == "" {
+			name = filepath.Join(os.Getenv("SYSTEMROOT"), "System32", "rundll32.exe")
+			args = []string{"url.dll,FileProtocolHandler", uri}
+			break
+		}
+		name = "cmd"
+		args = []string{"/C", "start", ""}
+		if opts.Wait {
+			args = append(args, "/wait")
+		}
+		args = append(args, opts.App)
+		args = append(args, opts.Args...)
+		args = append(args, strings.ReplaceAll(uri, "&", "^&"))
+	default:
+		if opts.App == "" {
+			name = "xdg-open"
+			args = []string{uri}
+			break
+		}
+		name = opts.App
+		args = append(args, opts.Args...)
+		args = append(args, uri)
+	}
+
+	if os.Getenv(open.OpenDisabledEnvKey) != "" {
+		return exec.Command("echo", fmt.Sprintf("%q", strings.Join(append([]string{name}, args...), " ")))
+	}
+	return exec.Command(name, args...) // #nosec G204
+}
+
+// Headless returns true if the OS default application cannot be used to open a URI because there is
+// no display or `xdg-open` is not installed. This is only detected on Linux and other Unix systems.
+func Headless() bool {
+	if os.Getenv(open.OpenDisabledEnvKey) != "" {
+		return false
+	}
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return false
+	}
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return true
+	}
+	_, err := exec.LookPath("xdg-open")
+	return err != nil
+}
+
+func launchName(opts LaunchOptions) string {
+	if opts.App != "" {
+		return opts.App
+	}
+	return "uri"
+}
@@ -1,6 +1,8 @@
 package open_test
 
 import (
+	"os"
+	"runtime"
 	"testing"
 
 	og "github.com/jahvon/open-golang/open"
@@ -47,3 +49,40 @@ var _ = Describe("OpenWith", func() {
 		})
 	})
 })
+
+var _ = Describe("Launch", func() {
+	It("should pass the app arguments before the uri", func() {
+		if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+			Skip("app commands are built differently on " + runtime.GOOS)
+		}
+		cmd := open.Command("http://example.com", open.LaunchOptions{App: "firefox", Args: []string{"--new-window"}})
+		out, err := cmd.Output()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("firefox --new-window http://example.com"))
+	})
+
+	When("wait is true", func() {
+		BeforeEach(func() {
+			if runtime.GOOS == "windows" {
+				Skip("exit status is not returned by start on windows")
+			}
+			Expect(os.Unsetenv(og.OpenDisabledEnvKey)).To(Succeed())
+			DeferCleanup(os.Setenv, og.OpenDisabledEnvKey, "true")
+		})
+
+		It("should return the exit status of the app", func() {
+			err := open.Launch("uri", open.LaunchOptions{App: "sh", Args: []string{"-c", "exit 3"}, Wait: true})
+			Expect(err).To(MatchError("sh exited with status 3"))
+		})
+
+		It("should set the env of the app", func() {
+			err := open.Launch("uri", open.LaunchOptions{
+				App:  "sh",
+				Args: []string{"-c", `test "$LAUNCH_VALUE" = "hello"`},
+				Env:  []string{"LAUNCH_VALUE=hello"},
+				Wait: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
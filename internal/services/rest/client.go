@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const DefaultMaxRedirects = 10
+
+// ClientConfig configures the transport used to send a request. A nil config uses the default client.
+type ClientConfig struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	// CertPEM and KeyPEM are used as the client certificate when CertFile and KeyFile are not set.
+	CertPEM []byte
+	KeyPEM  []byte
+
+	InsecureSkipVerify bool
+	Proxy              string
+	DisableRedirects   bool
+	// MaxRedirects is the number of redirects to follow. DefaultMaxRedirects is used when it is zero.
+	MaxRedirects int
+	DisableHTTP2 bool
+}
+
+func newClient(timeout time.Duration, streamBody bool, cfg *ClientConfig) (*http.Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if cfg == nil && !streamBody {
+		return client, nil
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unexpected default http transport")
+	}
+	transport = transport.Clone()
+	client.Transport = transport
+	if streamBody {
+		// The client timeout includes reading the body, so only the connection setup, which is bounded by the
+		// transport's dial and TLS handshake timeouts, and the response headers are timed.
+		client.Timeout = 0
+		transport.ResponseHeaderTimeout = timeout
+	}
+	if cfg == nil {
+		return client, nil
+	}
+
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %s - %w", cfg.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if cfg.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	maxRedirects := cfg.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
+		if cfg.DisableRedirects {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+	return client, nil
+}
+
+func (c *ClientConfig) tlsConfig() (*tls.Config, error) {
+	//nolint:gosec // skipping verification is an explicit opt-in
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
+
+	if c.CAFile != "" {
+		caData, err := os.ReadFile(filepath.Clean(c.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file %s - %w", c.CAFile, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("CA file %s does not contain any valid PEM encoded certificates", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case c.CertFile != "" || c.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(filepath.Clean(c.CertFile), filepath.Clean(c.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to load client certificate from %s and key from %s - %w", c.CertFile, c.KeyFile, err,
+			)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case len(c.CertPEM) > 0 || len(c.KeyPEM) > 0:
+		cert, err := tls.X509KeyPair(c.CertPEM, c.KeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate from secrets - %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// explainTLSError adds a hint on how to resolve common certificate verification failures.
+func explainTLSError(err error) error {
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var verifyErr *tls.CertificateVerificationError
+	switch {
+	case errors.As(err, &unknownAuthErr):
+		return fmt.Errorf(
+			"%w (the server certificate is signed by an unknown authority; "+
+				"set caFile to the CA bundle that signed it or insecureSkipVerify to skip verification)", err,
+		)
+	case errors.As(err, &hostnameErr):
+		return fmt.Errorf("%w (the server certificate is not valid for the requested host)", err)
+	case errors.As(err, &verifyErr):
+		return fmt.Errorf(
+			"%w (unable to verify the server certificate; "+
+				"set caFile to the CA bundle that signed it or insecureSkipVerify to skip verification)", err,
+		)
+	case strings.Contains(err.Error(), "remote error: tls:"):
+		return fmt.Errorf("%w (the server rejected the TLS handshake; check the client certificate and key)", err)
+	}
+	return err
+}
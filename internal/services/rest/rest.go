@@ -2,9 +2,11 @@ package rest
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 )
@@ -17,16 +19,140 @@ type Request struct {
 	URL     string
 	Method  string
 	Headers map[string]string
+	Query   map[string]string
 	Body    string
-	Timeout time.Duration
+	// BodyReader is used to stream the request body when set. It takes precedence over Body and is
+	// called each time the request is sent so that the body can be read again.
+	BodyReader func() (io.ReadCloser, error)
+	Timeout    time.Duration
+	// StreamBody limits Timeout to connecting and receiving the response headers, so that reading a large
+	// response body is not cut off.
+	StreamBody bool
+	Client     *ClientConfig
+	// Retry is used to resend the request when the server responds with a retryable status code.
+	Retry *RetryConfig
+}
+
+type Response struct {
+	StatusCode int
+	Status     string
+	Headers    http.Header
+	Body       string
+}
+
+// StatusError is returned when the response status code is not accepted. The body is truncated to
+// MaxErrorBodyLength bytes.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Headers    http.Header
+	Body       string
+}
+
+const MaxErrorBodyLength = 1024
+
+func NewStatusError(resp *Response) *StatusError {
+	body := resp.Body
+	if len(body) > MaxErrorBodyLength {
+		body = body[:MaxErrorBodyLength] + "... (truncated)"
+	}
+	status := resp.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return &StatusError{StatusCode: resp.StatusCode, Status: status, Headers: resp.Headers, Body: body}
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s: %s", ErrUnexpectedStatusCode, e.Status)
+	if body := strings.TrimSpace(e.Body); body != "" {
+		msg += "\n" + body
+	}
+	return msg
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrUnexpectedStatusCode
 }
 
 func SendRequest(reqSpec *Request, validStatusCodes []int) (string, error) {
+	resp, err := Send(reqSpec)
+	if err != nil {
+		return "", err
+	}
+	if !IsStatusCodeAccepted(resp.StatusCode, validStatusCodes) {
+		return "", NewStatusError(resp)
+	}
+	return resp.Body, nil
+}
+
+// Send sends the request and returns the response without validating the response status code.
+// If a retry config is set, the request is resent while the response status code is retryable.
+func Send(reqSpec *Request) (*Response, error) {
+	httpResp, err := Stream(reqSpec)
+	if err != nil {
+		return nil, err
+	}
+	return ReadResponse(httpResp)
+}
+
+// Stream sends the request and returns the response without reading its body. The caller is responsible for
+// closing the response body. If a retry config is set, the request is resent while the response status code
+// is retryable.
+func Stream(reqSpec *Request) (*http.Response, error) {
 	setRequestDefaults(reqSpec)
-	client := http.Client{Timeout: reqSpec.Timeout}
+	client, err := newClient(reqSpec.Timeout, reqSpec.StreamBody, reqSpec.Client)
+	if err != nil {
+		return nil, err
+	}
+	if reqSpec.Retry == nil {
+		return do(client, reqSpec)
+	}
+
+	retryCfg := reqSpec.Retry.withDefaults()
+	for attempt := 1; ; attempt++ {
+		httpResp, err := do(client, reqSpec)
+		if err != nil || attempt >= retryCfg.Attempts || !slices.Contains(retryCfg.StatusCodes, httpResp.StatusCode) {
+			return httpResp, err
+		}
+		resp, err := ReadResponse(httpResp)
+		if err != nil {
+			return nil, err
+		}
+		delay := retryCfg.delay(attempt, resp)
+		if retryCfg.OnRetry != nil {
+			retryCfg.OnRetry(attempt, resp, delay)
+		}
+		time.Sleep(delay)
+	}
+}
+
+// ReadResponse reads and closes the body of the response.
+func ReadResponse(httpResp *http.Response) (*Response, error) {
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Headers:    httpResp.Header,
+		Body:       string(respBody),
+	}, nil
+}
+
+func do(client *http.Client, reqSpec *Request) (*http.Response, error) {
 	reqURL, err := url.Parse(reqSpec.URL)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if len(reqSpec.Query) > 0 {
+		query := reqURL.Query()
+		for k, v := range reqSpec.Query {
+			query.Set(k, v)
+		}
+		reqURL.RawQuery = query.Encode()
 	}
 
 	headers := make(http.Header)
@@ -38,25 +164,22 @@ func SendRequest(reqSpec *Request, validStatusCodes []int) (string, error) {
 		URL:    reqURL,
 		Header: headers,
 	}
-	if reqSpec.Body != "" {
+	switch {
+	case reqSpec.BodyReader != nil:
+		req.Body, err = reqSpec.BodyReader()
+		if err != nil {
+			return nil, err
+		}
+	case reqSpec.Body != "":
 		req.Body = io.NopCloser(strings.NewReader(reqSpec.Body))
+		req.ContentLength = int64(len(reqSpec.Body))
 	}
 
 	httpResp, err := client.Do(&req)
 	if err != nil {
-		return "", err
-	}
-	defer httpResp.Body.Close()
-
-	if !isStatusCodeAccepted(httpResp.StatusCode, validStatusCodes) {
-		return "", ErrUnexpectedStatusCode
-	}
-
-	respBody, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return "", err
+		return nil, explainTLSError(err)
 	}
-	return string(respBody), nil
+	return httpResp, nil
 }
 
 func setRequestDefaults(req *Request) {
@@ -68,7 +191,7 @@ func setRequestDefaults(req *Request) {
 	}
 }
 
-func isStatusCodeAccepted(statusCode int, acceptedStatusCodes []int) bool {
+func IsStatusCodeAccepted(statusCode int, acceptedStatusCodes []int) bool {
 	if len(acceptedStatusCodes) == 0 {
 		return statusCode >= 200 && statusCode < 300
 	}
@@ -1,7 +1,13 @@
 package rest_test
 
 import (
+	"encoding/pem"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,7 +41,7 @@ var _ = Describe("Rest", func() {
 				Timeout: 30 * time.Second,
 			}
 			_, err := rest.SendRequest(req, []int{http.StatusOK})
-			Expect(err).To(Equal(rest.ErrUnexpectedStatusCode))
+			Expect(err).To(MatchError(rest.ErrUnexpectedStatusCode))
 		})
 
 		It("should return the correct body when a valid request is made", func() {
@@ -72,4 +78,166 @@ var _ = Describe("Rest", func() {
 			Expect(body).To(ContainSubstring("\"Test-Header\": \"Test-Value\""))
 		})
 	})
+
+	Context("Send with client config", func() {
+		var server *httptest.Server
+
+		AfterEach(func() {
+			if server != nil {
+				server.Close()
+			}
+		})
+
+		It("should explain certificate errors and trust the configured CA file", func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			}))
+			_, err := rest.Send(&rest.Request{URL: server.URL})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("caFile"))
+
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+			caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			Expect(os.WriteFile(caFile, caData, 0600)).To(Succeed())
+			resp, err := rest.Send(&rest.Request{URL: server.URL, Client: &rest.ClientConfig{CAFile: caFile}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("ok"))
+
+			resp, err = rest.Send(&rest.Request{URL: server.URL, Client: &rest.ClientConfig{InsecureSkipVerify: true}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("ok"))
+		})
+
+		It("should return an error when the CA file has no certificates", func() {
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+			Expect(os.WriteFile(caFile, []byte("not a cert"), 0600)).To(Succeed())
+			_, err := rest.Send(&rest.Request{URL: "https://localhost", Client: &rest.ClientConfig{CAFile: caFile}})
+			Expect(err).To(MatchError(ContainSubstring("does not contain any valid PEM encoded certificates")))
+		})
+
+		It("should respect the redirect settings", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/final" {
+					_, _ = w.Write([]byte("done"))
+					return
+				}
+				http.Redirect(w, r, "/final", http.StatusFound)
+			}))
+
+			resp, err := rest.Send(&rest.Request{URL: server.URL + "/start", Client: &rest.ClientConfig{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("done"))
+
+			resp, err = rest.Send(&rest.Request{
+				URL:    server.URL + "/start",
+				Client: &rest.ClientConfig{DisableRedirects: true},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusFound))
+			Expect(resp.Headers.Get("Location")).To(Equal("/final"))
+		})
+
+		It("should stop after the max number of redirects", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/loop", http.StatusFound)
+			}))
+			_, err := rest.Send(&rest.Request{URL: server.URL, Client: &rest.ClientConfig{MaxRedirects: 2}})
+			Expect(err).To(MatchError(ContainSubstring("stopped after 2 redirects")))
+		})
+	})
+
+	Context("Send with retry config", func() {
+		var server *httptest.Server
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should retry retryable status codes until the request succeeds", func() {
+			var attempts int
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if attempts < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			}))
+			var delays []time.Duration
+			resp, err := rest.Send(&rest.Request{
+				URL: server.URL,
+				Retry: &rest.RetryConfig{
+					Backoff: 10 * time.Millisecond,
+					OnRetry: func(_ int, _ *rest.Response, delay time.Duration) {
+						delays = append(delays, delay)
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("ok"))
+			Expect(attempts).To(Equal(3))
+			Expect(delays).To(Equal([]time.Duration{10 * time.Millisecond, 20 * time.Millisecond}))
+		})
+
+		It("should honor the Retry-After header and stop after the max attempts", func() {
+			var attempts int
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error": "slow down"}`))
+			}))
+			start := time.Now()
+			_, err := rest.SendRequest(&rest.Request{
+				URL:   server.URL,
+				Retry: &rest.RetryConfig{Attempts: 2, Backoff: time.Millisecond},
+			}, nil)
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+			Expect(attempts).To(Equal(2))
+
+			var statusErr *rest.StatusError
+			Expect(errors.As(err, &statusErr)).To(BeTrue())
+			Expect(statusErr.StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(statusErr.Headers.Get("Retry-After")).To(Equal("1"))
+			Expect(err.Error()).To(ContainSubstring("429 Too Many Requests"))
+			Expect(err.Error()).To(ContainSubstring("slow down"))
+		})
+
+		It("should cap the Retry-After delay at the max backoff", func() {
+			var attempts int
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			var delays []time.Duration
+			_, err := rest.SendRequest(&rest.Request{
+				URL: server.URL,
+				Retry: &rest.RetryConfig{
+					Attempts:   2,
+					MaxBackoff: 10 * time.Millisecond,
+					OnRetry: func(_ int, _ *rest.Response, delay time.Duration) {
+						delays = append(delays, delay)
+					},
+				},
+			}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(attempts).To(Equal(2))
+			Expect(delays).To(Equal([]time.Duration{10 * time.Millisecond}))
+		})
+
+		It("should not retry status codes that are not retryable", func() {
+			var attempts int
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(strings.Repeat("x", 2*rest.MaxErrorBodyLength)))
+			}))
+			_, err := rest.SendRequest(&rest.Request{URL: server.URL, Retry: &rest.RetryConfig{}}, nil)
+			Expect(attempts).To(Equal(1))
+			var statusErr *rest.StatusError
+			Expect(errors.As(err, &statusErr)).To(BeTrue())
+			Expect(statusErr.Body).To(HaveLen(rest.MaxErrorBodyLength + len("... (truncated)")))
+		})
+	})
 })
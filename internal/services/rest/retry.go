@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryBackoff    = time.Second
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryConfig configures how a request is retried when the server responds with a retryable status code.
+// Zero values are replaced with the package defaults.
+type RetryConfig struct {
+	Attempts    int
+	StatusCodes []int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// OnRetry is called before waiting for the next attempt.
+	OnRetry func(attempt int, resp *Response, delay time.Duration)
+}
+
+func (c *RetryConfig) withDefaults() RetryConfig {
+	cfg := *c
+	if cfg.Attempts <= 0 {
+		cfg.Attempts = DefaultRetryAttempts
+	}
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = DefaultRetryStatusCodes
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = DefaultRetryBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	return cfg
+}
+
+// delay returns the time to wait after the given attempt. The Retry-After header of the response takes
+// precedence over the exponential backoff. Both are capped at MaxBackoff.
+func (c *RetryConfig) delay(attempt int, resp *Response) time.Duration {
+	if d, ok := parseRetryAfter(resp.Headers.Get("Retry-After")); ok {
+		return min(d, c.MaxBackoff)
+	}
+	backoff := float64(c.Backoff) * math.Pow(2, float64(attempt-1))
+	if backoff > float64(c.MaxBackoff) {
+		return c.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
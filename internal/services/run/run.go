@@ -15,8 +15,10 @@ import (
 	"github.com/jahvon/tuikit/io"
 )
 
-// RunCmd executes a command in the current shell in a specific directory.
+// RunCmd executes a command in the current shell in a specific directory. The command is stopped when the
+// context is cancelled.
 func RunCmd(
+	ctx context.Context,
 	commandStr, dir string,
 	envList []string,
 	logMode io.LogMode,
@@ -26,14 +28,6 @@ func RunCmd(
 ) error {
 	logger.Debugf("running command in dir (%s):\n%s", dir, strings.TrimSpace(commandStr))
 
-	ctx := context.Background()
-	parser := syntax.NewParser()
-	reader := strings.NewReader(strings.TrimSpace(commandStr))
-	prog, err := parser.Parse(reader, "")
-	if err != nil {
-		return fmt.Errorf("unable to parse command - %w", err)
-	}
-
 	if envList == nil {
 		envList = make([]string, 0)
 	}
@@ -43,14 +37,38 @@ func RunCmd(
 	for k, v := range logFields {
 		flattenedFields = append(flattenedFields, k, v)
 	}
+	return RunCmdWithIO(
+		ctx,
+		commandStr,
+		dir,
+		envList,
+		stdIn,
+		stdOutWriter(logMode, logger, flattenedFields...),
+		stdErrWriter(logMode, logger, flattenedFields...),
+	)
+}
+
+// RunCmdWithIO executes a command with the given environment and IO in a specific directory. Unlike RunCmd, the
+// current environment is not added to the command's environment and its output is not logged. The command is
+// stopped when the context is cancelled.
+func RunCmdWithIO(
+	ctx context.Context,
+	commandStr, dir string,
+	envList []string,
+	stdIn stdio.Reader,
+	stdOut, stdErr stdio.Writer,
+) error {
+	parser := syntax.NewParser()
+	reader := strings.NewReader(strings.TrimSpace(commandStr))
+	prog, err := parser.Parse(reader, "")
+	if err != nil {
+		return fmt.Errorf("unable to parse command - %w", err)
+	}
+
 	runner, err := interp.New(
 		interp.Dir(dir),
 		interp.Env(expand.ListEnviron(envList...)),
-		interp.StdIO(
-			stdIn,
-			stdOutWriter(logMode, logger, flattenedFields...),
-			stdErrWriter(logMode, logger, flattenedFields...),
-		),
+		interp.StdIO(stdIn, stdOut, stdErr),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create runner - %w", err)
@@ -67,8 +85,10 @@ func RunCmd(
 	return nil
 }
 
-// RunFile executes a file in the current shell in a specific directory.
+// RunFile executes a file in the current shell in a specific directory. The file execution is stopped when the
+// context is cancelled.
 func RunFile(
+	ctx context.Context,
 	filename, dir string,
 	envList []string,
 	logMode io.LogMode,
@@ -78,7 +98,6 @@ func RunFile(
 ) error {
 	logger.Debugf("executing file (%s)", filepath.Join(dir, filename))
 
-	ctx := context.Background()
 	fullPath := filepath.Join(dir, filename)
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 		return fmt.Errorf("file does not exist - %s", fullPath)
@@ -1,6 +1,9 @@
 package run_test
 
 import (
+	"bytes"
+	"context"
+	stdio "io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -42,7 +45,7 @@ var _ = Describe("Run", func() {
 				logger.EXPECT().LogMode().DoAndReturn(func() tuikitIO.LogMode {
 					return tuikitIO.Hidden
 				}).AnyTimes()
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Hidden, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Hidden, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -53,7 +56,7 @@ var _ = Describe("Run", func() {
 					return tuikitIO.Text
 				}).AnyTimes()
 				logger.EXPECT().Println("foo").Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Text, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Text, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -64,7 +67,7 @@ var _ = Describe("Run", func() {
 					return tuikitIO.Logfmt
 				}).AnyTimes()
 				logger.EXPECT().Infof("foo", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -75,7 +78,7 @@ var _ = Describe("Run", func() {
 					return tuikitIO.JSON
 				}).AnyTimes()
 				logger.EXPECT().Infof("foo", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -87,7 +90,7 @@ var _ = Describe("Run", func() {
 				}).AnyTimes()
 				fields := map[string]interface{}{"key": "value"}
 				logger.EXPECT().Infox("foo", "key", "value").Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, fields)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, fields)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -99,12 +102,28 @@ var _ = Describe("Run", func() {
 				}).AnyTimes()
 				env := []string{"key=value"}
 				logger.EXPECT().Infof("value", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"$key\"", "", env, tuikitIO.JSON, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"$key\"", "", env, tuikitIO.JSON, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
 
+	Describe("RunCmdWithIO", func() {
+		It("should write the command output to the given writers", func() {
+			var stdOut, stdErr bytes.Buffer
+			err := run.RunCmdWithIO(
+				context.Background(), "echo \"$key\"; echo bar >&2", "", []string{"key=value"}, nil, &stdOut, &stdErr,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdOut.String()).To(Equal("value\n"))
+			Expect(stdErr.String()).To(Equal("bar\n"))
+		})
+		It("should return an error when the command fails", func() {
+			err := run.RunCmdWithIO(context.Background(), "exit 3", "", nil, nil, stdio.Discard, stdio.Discard)
+			Expect(err).To(MatchError("command exited with non-zero status 3"))
+		})
+	})
+
 	Describe("RunFile", func() {
 		var testfile *os.File
 
@@ -129,7 +148,7 @@ var _ = Describe("Run", func() {
 			logger.EXPECT().Println("foo").Times(1)
 			filename := filepath.Base(testfile.Name())
 			filedir := filepath.Dir(testfile.Name())
-			err := run.RunFile(filename, filedir, nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
+			err := run.RunFile(context.Background(), filename, filedir, nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
@@ -48,6 +48,22 @@ func NewStore() (Store, error) {
 	return &BoltStore{db: db}, nil
 }
 
+// NewBucketStore opens the store with the given bucket as the bucket that values are read from and written to.
+// Unlike CreateAndSetBucket, the bucket is not set as the process bucket of child processes.
+func NewBucketStore(id string) (Store, error) {
+	s, err := NewStore()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CreateBucket(id); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	bs, _ := s.(*BoltStore)
+	bs.processBucket = id
+	return bs, nil
+}
+
 // CreateBucket creates a bucket with a given id if it doesn't exist
 func (s *BoltStore) CreateBucket(id string) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
@@ -0,0 +1,239 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jahvon/tuikit/io"
+
+	"github.com/jahvon/flow/internal/filesystem"
+)
+
+const (
+	DefaultDebounce = 500 * time.Millisecond
+	DefaultInterval = 250 * time.Millisecond
+)
+
+// defaultIgnore is always ignored since the files in these directories change without being edited.
+var defaultIgnore = []string{".git"}
+
+// Options configure the files that are watched by a Watcher.
+type Options struct {
+	// Paths are absolute glob patterns for the files to watch. The `**` pattern matches any number of directories
+	// and a path to a directory matches all files in it.
+	Paths []string
+	// Ignore are glob patterns for the files and directories to skip. Patterns without a separator are matched
+	// against each element of the path, otherwise they must be absolute.
+	Ignore []string
+	// Excluded are the workspace's excluded paths. They are matched the same way as when discovering flowfiles.
+	Excluded []string
+	// WorkspacePath is the root of the workspace that excluded paths are relative to.
+	WorkspacePath string
+	// Debounce is how long the files must stay unchanged before a change is reported.
+	Debounce time.Duration
+	// Interval is how often the files are polled for changes.
+	Interval time.Duration
+}
+
+// Watcher polls the matching files for changes to their modification time, size, or existence.
+type Watcher struct {
+	logger   io.Logger
+	opts     Options
+	snapshot map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func NewWatcher(logger io.Logger, opts Options) *Watcher {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	opts.Ignore = append(slices.Clone(opts.Ignore), defaultIgnore...)
+	w := &Watcher{logger: logger, opts: opts}
+	w.snapshot = w.scan()
+	return w
+}
+
+// Files returns the sorted list of files that are currently watched.
+func (w *Watcher) Files() []string {
+	return slices.Sorted(maps.Keys(w.snapshot))
+}
+
+// Reset records the current state of the files. Changes are reported relative to the last reset.
+func (w *Watcher) Reset() {
+	w.snapshot = w.scan()
+}
+
+// Wait blocks until the watched files change and then stay unchanged for the debounce period. It returns the
+// files that were added, changed, or removed.
+func (w *Watcher) Wait(ctx context.Context) ([]string, error) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	var current map[string]fileState
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		next := w.scan()
+		switch {
+		case !maps.Equal(next, w.snapshotOrCurrent(current)):
+			current = next
+			lastChange = time.Now()
+		case current != nil && time.Since(lastChange) >= w.opts.Debounce:
+			changed := changedFiles(w.snapshot, current)
+			w.snapshot = current
+			if len(changed) == 0 {
+				// The files were changed back to their previous state.
+				current = nil
+				continue
+			}
+			return changed, nil
+		}
+	}
+}
+
+func (w *Watcher) snapshotOrCurrent(current map[string]fileState) map[string]fileState {
+	if current != nil {
+		return current
+	}
+	return w.snapshot
+}
+
+func (w *Watcher) scan() map[string]fileState {
+	files := make(map[string]fileState)
+	for _, pattern := range w.opts.Paths {
+		pattern = filepath.Clean(pattern)
+		root := staticPrefix(pattern)
+		info, err := os.Stat(root)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() && root == pattern {
+			pattern = filepath.Join(pattern, "**")
+		}
+		walkFunc := func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				w.logger.Debugf("unable to watch %s: %v", path, err)
+				return nil
+			}
+			if w.ignored(path) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.IsDir() || !Match(pattern, path) {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil
+			}
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		}
+		if err := filepath.WalkDir(root, walkFunc); err != nil {
+			w.logger.Debugf("unable to watch %s: %v", root, err)
+		}
+	}
+	return files
+}
+
+func (w *Watcher) ignored(path string) bool {
+	for _, pattern := range w.opts.Ignore {
+		if !strings.ContainsRune(pattern, filepath.Separator) {
+			for _, elem := range strings.Split(path, string(filepath.Separator)) {
+				if ok, _ := filepath.Match(pattern, elem); ok {
+					return true
+				}
+			}
+			continue
+		}
+		if Match(filepath.Clean(pattern), path) || Match(filepath.Join(filepath.Clean(pattern), "**"), path) {
+			return true
+		}
+	}
+	if len(w.opts.Excluded) > 0 && path != w.opts.WorkspacePath {
+		return filesystem.IsPathExcluded(w.logger, path, w.opts.WorkspacePath, w.opts.Excluded)
+	}
+	return false
+}
+
+// Match returns true if the path matches the glob pattern. In addition to the filepath.Match syntax, a `**`
+// element matches zero or more path elements.
+func Match(pattern, path string) bool {
+	sep := string(filepath.Separator)
+	return matchElems(strings.Split(pattern, sep), strings.Split(path, sep))
+}
+
+func matchElems(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchElems(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, err := filepath.Match(pattern[0], path[0]); err != nil || !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// staticPrefix returns the leading path elements of the pattern that do not contain glob characters.
+func staticPrefix(pattern string) string {
+	elems := strings.Split(pattern, string(filepath.Separator))
+	for i, elem := range elems {
+		if strings.ContainsAny(elem, "*?[") {
+			prefix := strings.Join(elems[:i], string(filepath.Separator))
+			if prefix == "" {
+				return string(filepath.Separator)
+			}
+			return prefix
+		}
+	}
+	return pattern
+}
+
+func changedFiles(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, found := before[path]; !found || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, found := after[path]; !found {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,113 @@
+package watch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jahvon/tuikit/io/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/services/watch"
+)
+
+func TestWatch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Watch Suite")
+}
+
+var _ = Describe("Match", func() {
+	DescribeTable("matching paths against glob patterns",
+		func(pattern, path string, expected bool) {
+			Expect(watch.Match(pattern, path)).To(Equal(expected))
+		},
+		Entry("exact path", "/ws/main.go", "/ws/main.go", true),
+		Entry("single element wildcard", "/ws/*.go", "/ws/main.go", true),
+		Entry("single element wildcard in a subdirectory", "/ws/*.go", "/ws/pkg/main.go", false),
+		Entry("double star in a subdirectory", "/ws/**/*.go", "/ws/pkg/sub/main.go", true),
+		Entry("double star without a subdirectory", "/ws/**/*.go", "/ws/main.go", true),
+		Entry("double star with a different extension", "/ws/**/*.go", "/ws/pkg/main.md", false),
+	)
+})
+
+var _ = Describe("Watcher", func() {
+	var (
+		logger *mocks.MockLogger
+		dir    string
+	)
+
+	BeforeEach(func() {
+		logger = mocks.NewMockLogger(gomock.NewController(GinkgoT()))
+		logger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+		dir = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(dir, "src"), 0750)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(dir, "build"), 0750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "src", "notes.md"), []byte("notes"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "build", "out.go"), []byte("package out"), 0600)).To(Succeed())
+	})
+
+	newWatcher := func(opts watch.Options) *watch.Watcher {
+		opts.Debounce = 50 * time.Millisecond
+		opts.Interval = 10 * time.Millisecond
+		return watch.NewWatcher(logger, opts)
+	}
+
+	It("should watch the files matching the paths", func() {
+		w := newWatcher(watch.Options{Paths: []string{filepath.Join(dir, "**", "*.go")}})
+		Expect(w.Files()).To(ConsistOf(
+			filepath.Join(dir, "src", "main.go"),
+			filepath.Join(dir, "build", "out.go"),
+		))
+	})
+
+	It("should watch all files in a directory path", func() {
+		w := newWatcher(watch.Options{Paths: []string{filepath.Join(dir, "src")}})
+		Expect(w.Files()).To(ConsistOf(
+			filepath.Join(dir, "src", "main.go"),
+			filepath.Join(dir, "src", "notes.md"),
+		))
+	})
+
+	It("should skip ignored and excluded paths", func() {
+		w := newWatcher(watch.Options{
+			Paths:         []string{dir},
+			Ignore:        []string{"*.md"},
+			Excluded:      []string{"//build"},
+			WorkspacePath: dir,
+		})
+		Expect(w.Files()).To(ConsistOf(filepath.Join(dir, "src", "main.go")))
+	})
+
+	It("should return the changed files", func() {
+		w := newWatcher(watch.Options{Paths: []string{filepath.Join(dir, "**", "*.go")}})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(50 * time.Millisecond)
+			Expect(os.WriteFile(filepath.Join(dir, "src", "notes.md"), []byte("ignored"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "src", "new.go"), []byte("package main"), 0600)).To(Succeed())
+			Expect(os.Remove(filepath.Join(dir, "build", "out.go"))).To(Succeed())
+		}()
+		changed, err := w.Wait(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(ConsistOf(
+			filepath.Join(dir, "src", "new.go"),
+			filepath.Join(dir, "build", "out.go"),
+		))
+	})
+
+	It("should stop waiting when the context is cancelled", func() {
+		w := newWatcher(watch.Options{Paths: []string{dir}})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := w.Wait(ctx)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
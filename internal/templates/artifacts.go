@@ -133,3 +133,24 @@ func copyArtifact(
 	}
 	return nil
 }
+
+// RenderFile renders the src file as a Go template with Sprig functions and writes the result to dst. The file keeps
+// the permissions of the src file and the directory of dst is created if it does not exist.
+func RenderFile(src, dst string, data map[string]string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	txt, err := os.ReadFile(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	buf, err := processAsGoTemplate(filepath.Base(src), string(txt), data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return errors.Wrap(err, "unable to create destination directory")
+	}
+	return os.WriteFile(dst, buf.Bytes(), info.Mode().Perm())
+}
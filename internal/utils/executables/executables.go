@@ -42,3 +42,63 @@ func ExecutableForCmd(parent *executable.Executable, cmd string, id int) *execut
 	exec.SetContext(parent.Workspace(), parent.WorkspacePath(), parent.Namespace(), parent.FlowFilePath())
 	return exec
 }
+
+func ExecutableForWait(parent *executable.Executable, wait *executable.WaitExecutableType, id int) *executable.Executable {
+	vis := executable.ExecutableVisibility(common.VisibilityInternal)
+	exec := &executable.Executable{
+		Verb:       "exec",
+		Name:       fmt.Sprintf("%s-wait-%d", parent.Name, id),
+		Visibility: &vis,
+		Wait:       wait,
+	}
+	exec.SetContext(parent.Workspace(), parent.WorkspacePath(), parent.Namespace(), parent.FlowFilePath())
+	return exec
+}
+
+// ExecutableTree returns the executable and the executables that its serial and parallel execs reference,
+// recursively. Each executable is included once, and refs that cannot be found are skipped.
+func ExecutableTree(ctx *context.Context, root *executable.Executable) []*executable.Executable {
+	tree := make([]*executable.Executable, 0)
+	seen := make(map[string]bool)
+	var visit func(e *executable.Executable)
+	visit = func(e *executable.Executable) {
+		if seen[e.Ref().String()] {
+			return
+		}
+		seen[e.Ref().String()] = true
+		tree = append(tree, e)
+		for _, step := range e.Steps() {
+			if step.Ref == "" {
+				continue
+			}
+			child, err := ctx.ExecutableCache.GetExecutableByRef(ctx.Logger, context.ExpandRef(ctx, step.Ref))
+			if err != nil || child == nil {
+				continue
+			}
+			visit(child)
+		}
+	}
+	visit(root)
+	return tree
+}
+
+// PromptParams returns the params with a prompt of the executable tree. Params that are inherited by more than one
+// executable, or that share an env key, are only included once.
+func PromptParams(ctx *context.Context, root *executable.Executable) executable.ParameterList {
+	var params executable.ParameterList
+	seen := make(map[string]bool)
+	for _, e := range ExecutableTree(ctx, root) {
+		env := e.Env()
+		if env == nil {
+			continue
+		}
+		for _, param := range env.Params {
+			if param.Prompt == "" || seen[param.EnvKey] {
+				continue
+			}
+			seen[param.EnvKey] = true
+			params = append(params, param)
+		}
+	}
+	return params
+}
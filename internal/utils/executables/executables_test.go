@@ -0,0 +1,74 @@
+package executables_test
+
+import (
+	stdCtx "context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/context"
+	execUtils "github.com/jahvon/flow/internal/utils/executables"
+	testUtils "github.com/jahvon/flow/tests/utils"
+	"github.com/jahvon/flow/types/executable"
+)
+
+func TestExecutables(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Executables Utils Suite")
+}
+
+var _ = Describe("Executables", func() {
+	var (
+		ctx      *testUtils.ContextWithMocks
+		flowFile *executable.FlowFile
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		flowFile = &executable.FlowFile{
+			Params: executable.ParameterList{{Prompt: "Which region?", EnvKey: "REGION"}},
+		}
+	})
+
+	newExec := func(name string, params executable.ParameterList, refs ...executable.Ref) *executable.Executable {
+		e := &executable.Executable{Verb: "run", Name: name}
+		if len(refs) == 0 {
+			e.Exec = &executable.ExecExecutableType{Cmd: "echo " + name, Params: params}
+		} else {
+			e.Serial = &executable.SerialExecutableType{Params: params}
+			for _, ref := range refs {
+				e.Serial.Execs = append(e.Serial.Execs, executable.SerialRefConfig{Ref: ref})
+			}
+		}
+		ws := ctx.Ctx.CurrentWorkspace
+		e.SetContext(ws.AssignedName(), ws.Location(), ctx.Ctx.Config.CurrentNamespace, ws.Location()+"/test.flow")
+		e.SetInheritedFields(flowFile)
+		ctx.ExecutableCache.EXPECT().
+			GetExecutableByRef(gomock.Any(), context.ExpandRef(ctx.Ctx, executable.NewRef(name, "run"))).
+			Return(e, nil).AnyTimes()
+		return e
+	}
+
+	Describe("PromptParams", func() {
+		It("should ask the inherited prompts of a serial executable once", func() {
+			newExec("first", executable.ParameterList{{Prompt: "Which name?", EnvKey: "NAME"}})
+			newExec("second", executable.ParameterList{{Prompt: "Which name?", EnvKey: "NAME"}})
+			root := newExec("root", nil, "run first", "run second")
+
+			params := execUtils.PromptParams(ctx.Ctx, root)
+			Expect(params).To(HaveLen(2))
+			Expect(params[0].EnvKey).To(Equal("REGION"))
+			Expect(params[1].EnvKey).To(Equal("NAME"))
+		})
+
+		It("should visit an executable that is referenced more than once or in a cycle once", func() {
+			newExec("first", nil, "run root")
+			root := newExec("root", nil, "run first", "run first")
+
+			Expect(execUtils.ExecutableTree(ctx.Ctx, root)).To(HaveLen(2))
+			Expect(execUtils.PromptParams(ctx.Ctx, root)).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jahvon/flow/internal/services/expr"
+	"github.com/jahvon/flow/types/executable"
+)
+
+// ReservedEnvPrefix is the prefix of the environment variables that flow sets for executables.
+const ReservedEnvPrefix = "FLOW_"
+
+func (v *validator) checkRefs(e *executable.Executable) {
+	for i, s := range e.Steps() {
+		if s.If != "" {
+			if err := expr.Check(s.If); err != nil {
+				v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf(
+					"invalid if expression in step %d: %v", i+1, err,
+				))
+			}
+		}
+		if s.Ref == "" {
+			continue
+		}
+		if _, err := v.resolve(e, s.Ref); err != nil {
+			v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf("unable to resolve ref %s: %v", s.Ref, err))
+		}
+	}
+}
+
+func (v *validator) checkParams(e *executable.Executable) {
+	env := e.Env()
+	if env == nil {
+		return
+	}
+	for _, param := range env.Params {
+		if strings.HasPrefix(param.EnvKey, ReservedEnvPrefix) {
+			v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf(
+				"param env key %s is reserved; keys starting with %s are set by flow", param.EnvKey, ReservedEnvPrefix,
+			))
+		}
+	}
+}
+
+func (v *validator) checkFiles(e *executable.Executable) {
+	if e.Exec != nil && e.Exec.File != "" {
+		v.checkFile(e, "file", e.Exec.Dir, e.Exec.File)
+	}
+	if e.Render != nil && e.Render.TemplateFile != "" {
+		v.checkFile(e, "templateFile", e.Render.Dir, e.Render.TemplateFile)
+	}
+}
+
+// checkFile reports a file that does not exist in the executable's directory. Directories that depend on the
+// environment or working directory at runtime are not checked.
+func (v *validator) checkFile(e *executable.Executable, field string, dir executable.Directory, file string) {
+	if dir == executable.TmpDirLabel || dir == "." || strings.HasPrefix(string(dir), "./") ||
+		strings.Contains(string(dir)+file, "$") {
+		return
+	}
+	targetDir, _, err := dir.ExpandDirectory(v.ctx.Logger, e.WorkspacePath(), e.FlowFilePath(), "", nil)
+	if err != nil {
+		v.addIssue(SeverityError, e.FlowFilePath(), e, err.Error())
+		return
+	}
+	path := filepath.Join(targetDir, file)
+	if _, err := os.Stat(path); err != nil {
+		v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf("%s %s does not exist", field, path))
+	}
+}
+
+// checkCycles reports refs that lead back to the executable that they are run from. Executables outside the flowfiles
+// are followed so that cycles through other workspaces are found.
+func (v *validator) checkCycles() {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int)
+	reported := make(map[string]bool)
+	var stack []*executable.Executable
+
+	var visit func(e *executable.Executable)
+	visit = func(e *executable.Executable) {
+		key := e.Ref().String()
+		state[key] = visiting
+		stack = append(stack, e)
+		for _, next := range v.children(e) {
+			nextKey := next.Ref().String()
+			switch state[nextKey] {
+			case visiting:
+				v.reportCycle(stack, next, reported)
+			case 0:
+				visit(next)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = visited
+	}
+	for _, e := range v.execs {
+		if state[e.Ref().String()] == 0 {
+			visit(e)
+		}
+	}
+}
+
+func (v *validator) reportCycle(
+	stack []*executable.Executable, start *executable.Executable, reported map[string]bool,
+) {
+	i := slices.IndexFunc(stack, func(e *executable.Executable) bool { return e.Ref() == start.Ref() })
+	cycle := stack[i:]
+	refs := make([]string, 0, len(cycle)+1)
+	for _, e := range cycle {
+		refs = append(refs, e.Ref().String())
+	}
+	members := slices.Clone(refs)
+	slices.Sort(members)
+	key := strings.Join(members, ",")
+	if reported[key] {
+		return
+	}
+	reported[key] = true
+	refs = append(refs, start.Ref().String())
+	v.addIssue(SeverityError, start.FlowFilePath(), start, "ref cycle: "+strings.Join(refs, " -> "))
+}
+
+// children returns the executables referenced by the executable. Refs that cannot be resolved are skipped since they
+// are reported separately.
+func (v *validator) children(e *executable.Executable) []*executable.Executable {
+	key := e.Ref().String()
+	if children, found := v.edges[key]; found {
+		return children
+	}
+	children := make([]*executable.Executable, 0)
+	for _, s := range e.Steps() {
+		if s.Ref == "" {
+			continue
+		}
+		if child, err := v.resolve(e, s.Ref); err == nil && child != nil {
+			children = append(children, child)
+		}
+	}
+	v.edges[key] = children
+	return children
+}
@@ -0,0 +1,201 @@
+// Package validation checks flowfiles for problems that would otherwise only be found when their executables are run.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/internal/context"
+	"github.com/jahvon/flow/internal/filesystem"
+	"github.com/jahvon/flow/types/executable"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Issue struct {
+	Severity   Severity `json:"severity"             yaml:"severity"`
+	File       string   `json:"file"                 yaml:"file"`
+	Executable string   `json:"executable,omitempty" yaml:"executable,omitempty"`
+	Message    string   `json:"message"              yaml:"message"`
+}
+
+type Report struct {
+	FlowFiles   int     `json:"flowFiles"   yaml:"flowFiles"`
+	Executables int     `json:"executables" yaml:"executables"`
+	Issues      []Issue `json:"issues"      yaml:"issues"`
+}
+
+// Errors returns the number of issues with the error severity.
+func (r *Report) Errors() int {
+	var count int
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *Report) YAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation report - %w", err)
+	}
+	return string(yamlBytes), nil
+}
+
+func (r *Report) JSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation report - %w", err)
+	}
+	return string(jsonBytes), nil
+}
+
+// validator holds the executables of the flowfiles being validated, indexed by their verb group and ID so that refs
+// using any related verb or alias can be resolved without the cache.
+type validator struct {
+	ctx     *context.Context
+	report  *Report
+	execs   executable.ExecutableList
+	primary map[string]*executable.Executable
+	aliases map[string]*executable.Executable
+	edges   map[string][]*executable.Executable
+}
+
+// Validate loads the flowfiles at the paths, which belong to the workspace, and reports the problems found in them.
+// Refs to executables outside the flowfiles are resolved from the cache.
+func Validate(ctx *context.Context, wsName, wsPath string, paths []string) *Report {
+	v := &validator{
+		ctx:     ctx,
+		report:  &Report{Issues: make([]Issue, 0)},
+		primary: make(map[string]*executable.Executable),
+		aliases: make(map[string]*executable.Executable),
+		edges:   make(map[string][]*executable.Executable),
+	}
+	slices.Sort(paths)
+	wsParams := v.workspaceParams(wsName, wsPath)
+	for _, path := range paths {
+		flowFile, err := filesystem.LoadFlowFile(path)
+		if err != nil {
+			v.addIssue(SeverityError, path, nil, err.Error())
+			continue
+		}
+		if err := filesystem.ResolveFlowFileImports(flowFile, path, wsPath); err != nil {
+			v.addIssue(SeverityError, path, nil, err.Error())
+			continue
+		}
+		flowFile.SetDefaults()
+		flowFile.SetWorkspaceParams(wsParams)
+		flowFile.SetContext(wsName, wsPath, path)
+		v.report.FlowFiles++
+		v.execs = append(v.execs, flowFile.Executables...)
+	}
+	v.report.Executables = len(v.execs)
+
+	v.indexExecutables()
+	for _, e := range v.execs {
+		if err := e.Validate(); err != nil {
+			v.addIssue(SeverityError, e.FlowFilePath(), e, err.Error())
+		}
+		v.checkParams(e)
+		v.checkFiles(e)
+		v.checkRefs(e)
+	}
+	v.checkCycles()
+	return v.report
+}
+
+func (v *validator) indexExecutables() {
+	for _, e := range v.execs {
+		key := refKey(e.Verb, e.ID())
+		if other, found := v.primary[key]; found {
+			v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf(
+				"duplicate executable; %s is also defined in %s", other.Ref(), other.FlowFilePath(),
+			))
+			continue
+		}
+		v.primary[key] = e
+	}
+	for _, e := range v.execs {
+		for _, id := range e.AliasesIDs() {
+			key := refKey(e.Verb, id)
+			switch other := v.primary[key]; {
+			case other == e:
+				continue
+			case other != nil:
+				v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf(
+					"alias %s collides with executable %s", id, other.Ref(),
+				))
+				continue
+			}
+			if other, found := v.aliases[key]; found && other != e {
+				v.addIssue(SeverityError, e.FlowFilePath(), e, fmt.Sprintf(
+					"alias %s is also an alias of %s", id, other.Ref(),
+				))
+				continue
+			}
+			v.aliases[key] = e
+		}
+	}
+}
+
+// resolve finds the executable that the ref of the parent points to. Refs without a workspace are resolved in the
+// parent's workspace.
+func (v *validator) resolve(parent *executable.Executable, ref executable.Ref) (*executable.Executable, error) {
+	expanded := context.ExpandRefInWorkspace(v.ctx, ref, parent.Workspace())
+	if err := expanded.Validate(); err != nil {
+		return nil, err
+	}
+	key := refKey(expanded.Verb(), expanded.ID())
+	if e, found := v.primary[key]; found {
+		return e, nil
+	} else if e, found := v.aliases[key]; found {
+		return e, nil
+	}
+	return v.ctx.ExecutableCache.GetExecutableByRef(v.ctx.Logger, expanded)
+}
+
+// workspaceParams returns the params that the executables of the workspace inherit. A workspace without a config
+// file has no params.
+func (v *validator) workspaceParams(wsName, wsPath string) executable.ParameterList {
+	cfgPath := filepath.Join(wsPath, filesystem.WorkspaceConfigFileName)
+	if _, err := os.Stat(cfgPath); err != nil {
+		return nil
+	}
+	wsCfg, err := filesystem.LoadWorkspaceConfig(wsName, wsPath)
+	if err != nil {
+		v.addIssue(SeverityError, cfgPath, nil, err.Error())
+		return nil
+	}
+	return wsCfg.InheritedParams()
+}
+
+func (v *validator) addIssue(severity Severity, file string, e *executable.Executable, msg string) {
+	issue := Issue{Severity: severity, File: file, Message: msg}
+	if e != nil {
+		issue.Executable = e.Ref().String()
+	}
+	v.report.Issues = append(v.report.Issues, issue)
+}
+
+// refKey identifies an executable by its ID and the group of its verb, since related verbs can be used
+// interchangeably in refs.
+func refKey(verb executable.Verb, id string) string {
+	group, found := executable.ValidVerbToGroupID[verb]
+	if !found {
+		group = verb.String()
+	}
+	return strings.Join([]string{group, id}, " ")
+}
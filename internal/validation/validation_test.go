@@ -0,0 +1,159 @@
+package validation_test
+
+import (
+	stdCtx "context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jahvon/flow/internal/validation"
+	testUtils "github.com/jahvon/flow/tests/utils"
+)
+
+func TestValidation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Validation Suite")
+}
+
+var _ = Describe("Validate", func() {
+	var (
+		ctx   *testUtils.ContextWithMocks
+		wsDir string
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoT())
+		ctx.ExecutableCache.EXPECT().GetExecutableByRef(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("not found")).AnyTimes()
+		wsDir = GinkgoT().TempDir()
+	})
+
+	writeFlowFile := func(name, content string) string {
+		path := filepath.Join(wsDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	messages := func(report *validation.Report) []string {
+		msgs := make([]string, 0)
+		for _, issue := range report.Issues {
+			msgs = append(msgs, issue.Message)
+		}
+		return msgs
+	}
+
+	It("should not report issues for valid flowfiles", func() {
+		Expect(os.WriteFile(filepath.Join(wsDir, "build.sh"), []byte("echo build"), 0600)).To(Succeed())
+		path := writeFlowFile("app.flow", `
+namespace: app
+executables:
+  - verb: build
+    name: api
+    exec:
+      file: build.sh
+  - verb: deploy
+    name: api
+    serial:
+      execs:
+        - ref: compile ws/app:api
+          if: os == "linux" && len(store["key"]) > 0
+        - cmd: echo done
+`)
+		report := validation.Validate(ctx.Ctx, "ws", wsDir, []string{path})
+		Expect(report.Issues).To(BeEmpty())
+		Expect(report.FlowFiles).To(Equal(1))
+		Expect(report.Executables).To(Equal(2))
+	})
+
+	It("should report duplicate executables and alias collisions", func() {
+		first := writeFlowFile("first.flow", `
+executables:
+  - verb: build
+    name: api
+    aliases: [server]
+    exec:
+      cmd: make
+  - verb: build
+    name: server
+    exec:
+      cmd: make
+`)
+		second := writeFlowFile("second.flow", `
+executables:
+  - verb: compile
+    name: api
+    exec:
+      cmd: make
+`)
+		report := validation.Validate(ctx.Ctx, "ws", wsDir, []string{first, second})
+		Expect(report.Errors()).To(Equal(2))
+		Expect(report.Issues[0].File).To(Equal(second))
+		Expect(report.Issues[0].Message).To(ContainSubstring("duplicate executable; build ws/api is also defined"))
+		Expect(report.Issues[1].Message).To(Equal("alias ws/server collides with executable build ws/server"))
+	})
+
+	It("should report refs that cannot be resolved and ref cycles", func() {
+		path := writeFlowFile("refs.flow", `
+executables:
+  - verb: build
+    name: a
+    serial:
+      execs:
+        - ref: build ws/b
+        - ref: build ws/missing
+  - verb: build
+    name: b
+    parallel:
+      execs:
+        - ref: build ws/a
+`)
+		report := validation.Validate(ctx.Ctx, "ws", wsDir, []string{path})
+		Expect(messages(report)).To(ConsistOf(
+			"unable to resolve ref build ws/missing: not found",
+			"ref cycle: build ws/a -> build ws/b -> build ws/a",
+		))
+	})
+
+	It("should report invalid expressions, missing files, and reserved env keys", func() {
+		path := writeFlowFile("checks.flow", `
+executables:
+  - verb: run
+    name: script
+    exec:
+      file: missing.sh
+      params:
+        - envKey: FLOW_TOKEN
+          text: value
+  - verb: show
+    name: page
+    render:
+      templateFile: page.md
+  - verb: run
+    name: steps
+    serial:
+      execs:
+        - cmd: echo hi
+          if: unknown.value
+`)
+		report := validation.Validate(ctx.Ctx, "ws", wsDir, []string{path})
+		msgs := messages(report)
+		Expect(msgs).To(HaveLen(4))
+		Expect(msgs).To(ContainElement(ContainSubstring("file " + filepath.Join(wsDir, "missing.sh") + " does not exist")))
+		Expect(msgs).To(ContainElement(ContainSubstring("templateFile " + filepath.Join(wsDir, "page.md"))))
+		Expect(msgs).To(ContainElement(ContainSubstring("param env key FLOW_TOKEN is reserved")))
+		Expect(msgs).To(ContainElement(ContainSubstring("invalid if expression in step 1: unknown name unknown")))
+	})
+
+	It("should report flowfiles that cannot be loaded", func() {
+		path := writeFlowFile("broken.flow", "executables: [")
+		report := validation.Validate(ctx.Ctx, "ws", wsDir, []string{path})
+		Expect(report.Errors()).To(Equal(1))
+		Expect(report.Issues[0].File).To(Equal(path))
+		Expect(report.FlowFiles).To(BeZero())
+	})
+})
@@ -53,7 +53,11 @@ type ContextWithMocks struct {
 // NewContextWithMocks creates a new context for testing runners. It initializes the context with
 // a mock logger and mock caches. The mock logger is set to expect debug calls.
 func NewContextWithMocks(ctx stdCtx.Context, t ginkgo.FullGinkgoTInterface) *ContextWithMocks {
-	null := os.NewFile(0, os.DevNull)
+	null, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("unable to open %s: %v", os.DevNull, err)
+	}
+	t.Cleanup(func() { _ = null.Close() })
 	configDir, cacheDir, wsDir := initTestDirectories(t)
 	setTestEnv(t, configDir, cacheDir)
 	testWsCfg, err := testWsConfig(wsDir)
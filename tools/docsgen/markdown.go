@@ -130,6 +130,8 @@ func typeStr(s *schema.JSONSchema) string {
 	}
 	standard := []string{"string", "integer", "number", "boolean", "object"}
 	switch {
+	case name == "":
+		return "`any`"
 	case name == "array":
 		return fmt.Sprintf("`array` (%s)", typeStr(s.Items))
 	case name == "object" && s.AdditionalProperties != nil:
@@ -49,6 +49,9 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 	if src.Items != nil {
 		MergeSchemas(dst, src.Items, dstFile, schemaMap)
 	}
+	if src.AdditionalProperties != nil {
+		MergeSchemas(dst, src.AdditionalProperties, dstFile, schemaMap)
+	}
 	for _, value := range src.Definitions {
 		if value.Ref.IsRoot() {
 			continue
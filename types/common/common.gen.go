@@ -5,6 +5,56 @@ package common
 // Alternate names that can be used to reference the executable in the CLI.
 type Aliases []string
 
+// Configuration for the HTTP client used to send requests.
+// File paths are resolved with the same rules as the executable `dir` field.
+type HTTPClientConfig struct {
+	// The path to a PEM encoded CA certificate bundle used to verify the server's
+	// certificate.
+	CaFile string `json:"caFile,omitempty" yaml:"caFile,omitempty" mapstructure:"caFile,omitempty"`
+
+	// The path to a PEM encoded client certificate used for mutual TLS. Must be set
+	// with `keyFile`.
+	//
+	CertFile string `json:"certFile,omitempty" yaml:"certFile,omitempty" mapstructure:"certFile,omitempty"`
+
+	// A reference to a secret containing the PEM encoded client certificate. Must be
+	// set with `keySecretRef`.
+	//
+	CertSecretRef string `json:"certSecretRef,omitempty" yaml:"certSecretRef,omitempty" mapstructure:"certSecretRef,omitempty"`
+
+	// If set to false, redirect responses will be returned instead of followed.
+	// Defaults to true.
+	FollowRedirects *bool `json:"followRedirects,omitempty" yaml:"followRedirects,omitempty" mapstructure:"followRedirects,omitempty"`
+
+	// If set to false, HTTP/2 will not be negotiated with the server. Defaults to
+	// true.
+	Http2 *bool `json:"http2,omitempty" yaml:"http2,omitempty" mapstructure:"http2,omitempty"`
+
+	// If set to true, the server's certificate will not be verified. This should only
+	// be used for testing.
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty" mapstructure:"insecureSkipVerify,omitempty"`
+
+	// The path to the PEM encoded private key of the client certificate. Must be set
+	// with `certFile`.
+	//
+	KeyFile string `json:"keyFile,omitempty" yaml:"keyFile,omitempty" mapstructure:"keyFile,omitempty"`
+
+	// A reference to a secret containing the PEM encoded private key of the client
+	// certificate.
+	// Must be set with `certSecretRef`.
+	//
+	KeySecretRef string `json:"keySecretRef,omitempty" yaml:"keySecretRef,omitempty" mapstructure:"keySecretRef,omitempty"`
+
+	// The maximum number of redirects to follow. Defaults to 10.
+	MaxRedirects *int `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty" mapstructure:"maxRedirects,omitempty"`
+
+	// The URL of the proxy to send requests through. If not set, the `HTTP_PROXY`,
+	// `HTTPS_PROXY` and `NO_PROXY`
+	// environment variables are used.
+	//
+	Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+}
+
 // A list of tags.
 // Tags can be used with list commands to filter returned data.
 type Tags []string
@@ -73,3 +73,112 @@ func (v Visibility) IsInternal() bool {
 func (v Visibility) IsHidden() bool {
 	return v == VisibilityHidden
 }
+
+// Merge returns a copy of the config with the fields set in the override config taking precedence.
+// Client certificate fields are overridden as a group so that file and secret sources are not mixed.
+func (c *HTTPClientConfig) Merge(override *HTTPClientConfig) *HTTPClientConfig {
+	switch {
+	case c == nil && override == nil:
+		return nil
+	case c == nil:
+		merged := *override
+		return &merged
+	case override == nil:
+		merged := *c
+		return &merged
+	}
+
+	merged := *c
+	if override.CaFile != "" {
+		merged.CaFile = override.CaFile
+	}
+	if override.CertFile != "" || override.KeyFile != "" ||
+		override.CertSecretRef != "" || override.KeySecretRef != "" {
+		merged.CertFile = override.CertFile
+		merged.KeyFile = override.KeyFile
+		merged.CertSecretRef = override.CertSecretRef
+		merged.KeySecretRef = override.KeySecretRef
+	}
+	if override.InsecureSkipVerify != nil {
+		merged.InsecureSkipVerify = override.InsecureSkipVerify
+	}
+	if override.Proxy != "" {
+		merged.Proxy = override.Proxy
+	}
+	if override.FollowRedirects != nil {
+		merged.FollowRedirects = override.FollowRedirects
+	}
+	if override.MaxRedirects != nil {
+		merged.MaxRedirects = override.MaxRedirects
+	}
+	if override.Http2 != nil {
+		merged.Http2 = override.Http2
+	}
+	return &merged
+}
+
+func (c *HTTPClientConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("certFile and keyFile must be set together")
+	}
+	if (c.CertSecretRef == "") != (c.KeySecretRef == "") {
+		return fmt.Errorf("certSecretRef and keySecretRef must be set together")
+	}
+	if c.CertFile != "" && c.CertSecretRef != "" {
+		return fmt.Errorf("only one of certFile/keyFile or certSecretRef/keySecretRef can be set")
+	}
+	if c.MaxRedirects != nil && *c.MaxRedirects < 0 {
+		return fmt.Errorf("maxRedirects cannot be negative")
+	}
+	return nil
+}
+
+// SecretRefs returns the vault secret references used by the config.
+func (c *HTTPClientConfig) SecretRefs() []string {
+	if c == nil {
+		return nil
+	}
+	var refs []string
+	if c.CertSecretRef != "" {
+		refs = append(refs, c.CertSecretRef)
+	}
+	if c.KeySecretRef != "" {
+		refs = append(refs, c.KeySecretRef)
+	}
+	return refs
+}
+
+// Markdown returns a markdown list describing the settings that differ from the default client.
+func (c *HTTPClientConfig) Markdown() string {
+	if c == nil {
+		return ""
+	}
+	var mkdwn string
+	if c.CaFile != "" {
+		mkdwn += fmt.Sprintf("- CA file: `%s`\n", c.CaFile)
+	}
+	if c.CertFile != "" {
+		mkdwn += fmt.Sprintf("- Client certificate: `%s` (key `%s`)\n", c.CertFile, c.KeyFile)
+	}
+	if c.CertSecretRef != "" {
+		mkdwn += fmt.Sprintf("- Client certificate secret: `%s` (key secret `%s`)\n", c.CertSecretRef, c.KeySecretRef)
+	}
+	if c.InsecureSkipVerify != nil && *c.InsecureSkipVerify {
+		mkdwn += "- *Server certificate verification is disabled*\n"
+	}
+	if c.Proxy != "" {
+		mkdwn += fmt.Sprintf("- Proxy: %s\n", c.Proxy)
+	}
+	if c.FollowRedirects != nil && !*c.FollowRedirects {
+		mkdwn += "- Redirects are not followed\n"
+	} else if c.MaxRedirects != nil {
+		mkdwn += fmt.Sprintf("- Max redirects: %d\n", *c.MaxRedirects)
+	}
+	if c.Http2 != nil && !*c.Http2 {
+		mkdwn += "- HTTP/2 is disabled\n"
+	}
+	return mkdwn
+}
@@ -2,6 +2,7 @@
 
 package config
 
+import "github.com/jahvon/flow/types/common"
 import "github.com/jahvon/tuikit/io"
 import "time"
 
@@ -101,9 +102,17 @@ type Config struct {
 	//
 	DefaultTimeout time.Duration `json:"defaultTimeout,omitempty" yaml:"defaultTimeout,omitempty" mapstructure:"defaultTimeout,omitempty"`
 
+	// The default configuration for the HTTP client used by request executables.
+	// Fields set in an executable's `httpClient` override these values.
+	//
+	HttpClient *ConfigHttpClient `json:"httpClient,omitempty" yaml:"httpClient,omitempty" mapstructure:"httpClient,omitempty"`
+
 	// Interactive corresponds to the JSON schema field "interactive".
 	Interactive *Interactive `json:"interactive,omitempty" yaml:"interactive,omitempty" mapstructure:"interactive,omitempty"`
 
+	// Server corresponds to the JSON schema field "server".
+	Server *ServerConfig `json:"server,omitempty" yaml:"server,omitempty" mapstructure:"server,omitempty"`
+
 	// A map of flowfile template names to their paths.
 	Templates ConfigTemplates `json:"templates,omitempty" yaml:"templates,omitempty" mapstructure:"templates,omitempty"`
 
@@ -125,6 +134,10 @@ type Config struct {
 	Workspaces ConfigWorkspaces `json:"workspaces" yaml:"workspaces" mapstructure:"workspaces"`
 }
 
+// The default configuration for the HTTP client used by request executables.
+// Fields set in an executable's `httpClient` override these values.
+type ConfigHttpClient common.HTTPClientConfig
+
 // A map of flowfile template names to their paths.
 type ConfigTemplates map[string]string
 
@@ -156,3 +169,29 @@ type Interactive struct {
 	// Whether to play a sound when a command completes.
 	SoundOnCompletion *bool `json:"soundOnCompletion,omitempty" yaml:"soundOnCompletion,omitempty" mapstructure:"soundOnCompletion,omitempty"`
 }
+
+// Configurations for the REST API that is started with `flow serve`.
+type ServerConfig struct {
+	// The address that the server listens on. Use `0.0.0.0:<port>` to accept remote
+	// connections.
+	Address string `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// References of the executables that can be run through the API (e.g. `deploy
+	// my-ws/app:api`).
+	// The ID may also be used on its own to allow any verb.
+	//
+	AllowedExecutables []string `json:"allowedExecutables,omitempty" yaml:"allowedExecutables,omitempty" mapstructure:"allowedExecutables,omitempty"`
+
+	// Executables with one of these visibilities can be run through the API. Hidden
+	// executables can never be run
+	// through the API. If neither this nor `allowedExecutables` is set, no
+	// executables can be run.
+	//
+	AllowedVisibilities []ServerConfigAllowedVisibilitiesElem `json:"allowedVisibilities,omitempty" yaml:"allowedVisibilities,omitempty" mapstructure:"allowedVisibilities,omitempty"`
+}
+
+type ServerConfigAllowedVisibilitiesElem string
+
+const ServerConfigAllowedVisibilitiesElemInternal ServerConfigAllowedVisibilitiesElem = "internal"
+const ServerConfigAllowedVisibilitiesElemPrivate ServerConfigAllowedVisibilitiesElem = "private"
+const ServerConfigAllowedVisibilitiesElemPublic ServerConfigAllowedVisibilitiesElem = "public"
@@ -3,15 +3,22 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"slices"
+	"strings"
 
 	tuikitIO "github.com/jahvon/tuikit/io"
 	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
+
+	"github.com/jahvon/flow/types/common"
 )
 
 //go:generate go run github.com/atombender/go-jsonschema@v0.16.0 -et --only-models -p config -o config.gen.go schema.yaml
 
+// DefaultServerAddress is the address that `flow serve` listens on when one is not configured.
+const DefaultServerAddress = "127.0.0.1:8471"
+
 func (c *Config) Validate() error {
 	if c.CurrentWorkspace == "" {
 		if _, found := c.Workspaces["default"]; found {
@@ -31,6 +38,14 @@ func (c *Config) Validate() error {
 	if err := c.DefaultLogMode.Validate(); err != nil {
 		return err
 	}
+	if err := c.HTTPClientConfig().Validate(); err != nil {
+		return fmt.Errorf("invalid httpClient config - %w", err)
+	}
+	if c.Server != nil && c.Server.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Server.Address); err != nil {
+			return fmt.Errorf("invalid server address - %w", err)
+		}
+	}
 
 	return nil
 }
@@ -50,6 +65,21 @@ func (c *Config) SetDefaults() {
 	}
 }
 
+func (c *Config) HTTPClientConfig() *common.HTTPClientConfig {
+	if c == nil {
+		return nil
+	}
+	return (*common.HTTPClientConfig)(c.HttpClient)
+}
+
+// ServerAddress returns the address that `flow serve` listens on.
+func (c *Config) ServerAddress() string {
+	if c == nil || c.Server == nil || c.Server.Address == "" {
+		return DefaultServerAddress
+	}
+	return c.Server.Address
+}
+
 func (c *Config) ShowTUI() bool {
 	return c.Interactive != nil && c.Interactive.Enabled
 }
@@ -116,6 +146,23 @@ func (c *Config) Markdown() string {
 			mkdwn += "**Interactive mode is disabled**\n"
 		}
 	}
+	if client := c.HTTPClientConfig().Markdown(); client != "" {
+		mkdwn += "## HTTP Client Settings\n" + client
+	}
+	if c.Server != nil {
+		mkdwn += "## Server Settings\n"
+		mkdwn += fmt.Sprintf("**Address**: %s\n", c.ServerAddress())
+		if len(c.Server.AllowedExecutables) > 0 {
+			mkdwn += fmt.Sprintf("**Allowed executables**: %s\n", strings.Join(c.Server.AllowedExecutables, ", "))
+		}
+		if len(c.Server.AllowedVisibilities) > 0 {
+			visibilities := make([]string, 0, len(c.Server.AllowedVisibilities))
+			for _, v := range c.Server.AllowedVisibilities {
+				visibilities = append(visibilities, string(v))
+			}
+			mkdwn += fmt.Sprintf("**Allowed visibilities**: %s\n", strings.Join(visibilities, ", "))
+		}
+	}
 	mkdwn += "## Registered Workspaces\n"
 	allWs := make([]string, 0, len(c.Workspaces))
 	for name := range c.Workspaces {
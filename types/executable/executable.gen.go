@@ -6,6 +6,7 @@ import "github.com/jahvon/flow/types/common"
 import "github.com/jahvon/tuikit/io"
 import "time"
 
+// The `envKey` is required unless the argument is a `fragment`.
 type Argument struct {
 	// The default value to use if the argument is not provided.
 	// If the argument is required and no default is provided, the executable will
@@ -14,13 +15,19 @@ type Argument struct {
 	Default string `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
 
 	// The name of the environment variable that will be assigned the value.
-	EnvKey string `json:"envKey" yaml:"envKey" mapstructure:"envKey"`
+	EnvKey string `json:"envKey,omitempty" yaml:"envKey,omitempty" mapstructure:"envKey,omitempty"`
 
 	// The flag to use when setting the argument from the command line.
 	// Either `flag` or `pos` must be set, but not both.
 	//
 	Flag string `json:"flag,omitempty" yaml:"flag,omitempty" mapstructure:"flag,omitempty"`
 
+	// The name of an args fragment defined in the flow file, or in one of its
+	// imports. The argument is replaced
+	// with the arguments of the fragment and all other fields are ignored.
+	//
+	Fragment string `json:"fragment,omitempty" yaml:"fragment,omitempty" mapstructure:"fragment,omitempty"`
+
 	// The position of the argument in the command line ArgumentList. Values start at
 	// 1.
 	// Either `flag` or `pos` must be set, but not both.
@@ -105,9 +112,15 @@ type Executable struct {
 	// Exec corresponds to the JSON schema field "exec".
 	Exec *ExecExecutableType `json:"exec,omitempty" yaml:"exec,omitempty" mapstructure:"exec,omitempty"`
 
+	// flowFileParams corresponds to the JSON schema field "flowFileParams".
+	flowFileParams ParameterList `json:"flowFileParams,omitempty" yaml:"flowFileParams,omitempty" mapstructure:"flowFileParams,omitempty"`
+
 	// flowFilePath corresponds to the JSON schema field "flowFilePath".
 	flowFilePath string `json:"flowFilePath,omitempty" yaml:"flowFilePath,omitempty" mapstructure:"flowFilePath,omitempty"`
 
+	// Fs corresponds to the JSON schema field "fs".
+	Fs *FsExecutableType `json:"fs,omitempty" yaml:"fs,omitempty" mapstructure:"fs,omitempty"`
+
 	// inheritedDescription corresponds to the JSON schema field
 	// "inheritedDescription".
 	inheritedDescription string `json:"inheritedDescription,omitempty" yaml:"inheritedDescription,omitempty" mapstructure:"inheritedDescription,omitempty"`
@@ -135,9 +148,23 @@ type Executable struct {
 	// Request corresponds to the JSON schema field "request".
 	Request *RequestExecutableType `json:"request,omitempty" yaml:"request,omitempty" mapstructure:"request,omitempty"`
 
+	// A cron expression for running the executable on a recurring schedule with the
+	// `flow daemon` command.
+	// Standard 5-field expressions (minute, hour, day of month, month, day of week)
+	// and the `@hourly`, `@daily`,
+	// `@weekly`, `@monthly`, and `@yearly` descriptors are supported. The schedule
+	// uses the local time zone.
+	//
+	// For example, `0 9 * * mon-fri` runs the executable at 9am on weekdays.
+	//
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule,omitempty"`
+
 	// Serial corresponds to the JSON schema field "serial".
 	Serial *SerialExecutableType `json:"serial,omitempty" yaml:"serial,omitempty" mapstructure:"serial,omitempty"`
 
+	// Service corresponds to the JSON schema field "service".
+	Service *ServiceExecutableType `json:"service,omitempty" yaml:"service,omitempty" mapstructure:"service,omitempty"`
+
 	// Tags corresponds to the JSON schema field "tags".
 	Tags ExecutableTags `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
@@ -153,9 +180,18 @@ type Executable struct {
 	// Visibility corresponds to the JSON schema field "visibility".
 	Visibility *ExecutableVisibility `json:"visibility,omitempty" yaml:"visibility,omitempty" mapstructure:"visibility,omitempty"`
 
+	// Wait corresponds to the JSON schema field "wait".
+	Wait *WaitExecutableType `json:"wait,omitempty" yaml:"wait,omitempty" mapstructure:"wait,omitempty"`
+
+	// Watch corresponds to the JSON schema field "watch".
+	Watch *WatchConfig `json:"watch,omitempty" yaml:"watch,omitempty" mapstructure:"watch,omitempty"`
+
 	// workspace corresponds to the JSON schema field "workspace".
 	workspace string `json:"workspace,omitempty" yaml:"workspace,omitempty" mapstructure:"workspace,omitempty"`
 
+	// workspaceParams corresponds to the JSON schema field "workspaceParams".
+	workspaceParams ParameterList `json:"workspaceParams,omitempty" yaml:"workspaceParams,omitempty" mapstructure:"workspaceParams,omitempty"`
+
 	// workspacePath corresponds to the JSON schema field "workspacePath".
 	workspacePath string `json:"workspacePath,omitempty" yaml:"workspacePath,omitempty" mapstructure:"workspacePath,omitempty"`
 }
@@ -166,11 +202,102 @@ type ExecutableTags common.Tags
 
 type ExecutableVisibility common.Visibility
 
+// Runs a list of portable filesystem operations in order.
+type FsExecutableType struct {
+	// Args corresponds to the JSON schema field "args".
+	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// The operations to run. The executable stops at the first operation that fails.
+	Ops FsOperationList `json:"ops" yaml:"ops" mapstructure:"ops"`
+
+	// Params corresponds to the JSON schema field "params".
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+}
+
+// A filesystem operation. Only one of `copy`, `move`, `mkdir`, `remove`,
+// `template`, `symlink`, or `chmod`
+// must be set.
+//
+// Paths are expanded with the same rules as the executable `dir` field: `//` is
+// the workspace root, `~/` is the
+// home directory, `f:tmp` is the process's temporary directory, and other relative
+// paths are relative to the
+// flow file's directory. Environment variables, including parameters and
+// arguments, are expanded.
+type FsOperation struct {
+	// Sets the permissions of the matching files and directories to `mode`.
+	Chmod *FsPath `json:"chmod,omitempty" yaml:"chmod,omitempty" mapstructure:"chmod,omitempty"`
+
+	// Copies a file or directory. The `src` can be a glob pattern; when it matches
+	// more than one path or `dst`
+	// is an existing directory, the paths are copied into `dst`.
+	//
+	Copy *FsTransfer `json:"copy,omitempty" yaml:"copy,omitempty" mapstructure:"copy,omitempty"`
+
+	// Creates a directory and any missing parent directories.
+	Mkdir *FsPath `json:"mkdir,omitempty" yaml:"mkdir,omitempty" mapstructure:"mkdir,omitempty"`
+
+	// Moves a file or directory.
+	Move *FsTransfer `json:"move,omitempty" yaml:"move,omitempty" mapstructure:"move,omitempty"`
+
+	// Removes the matching files and directories. The removal must be confirmed
+	// unless `force` is set or the
+	// `--yes` flag is used.
+	//
+	Remove *FsPath `json:"remove,omitempty" yaml:"remove,omitempty" mapstructure:"remove,omitempty"`
+
+	// Creates a symbolic link at `dst` that points to `src`. An existing link at
+	// `dst` is replaced.
+	Symlink *FsTransfer `json:"symlink,omitempty" yaml:"symlink,omitempty" mapstructure:"symlink,omitempty"`
+
+	// Renders the `src` file as a Go template and writes it to `dst`. The environment
+	// variables are available
+	// as data (e.g. `{{ .APP_NAME }}`) and [Sprig
+	// functions](https://masterminds.github.io/sprig/) can be used.
+	//
+	Template *FsTransfer `json:"template,omitempty" yaml:"template,omitempty" mapstructure:"template,omitempty"`
+}
+
+type FsOperationList []FsOperation
+
+// A path that a filesystem operation is applied to.
+type FsPath struct {
+	// If set to true, `remove` does not ask for confirmation before removing the
+	// path.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty" mapstructure:"force,omitempty"`
+
+	// The permissions to set, in octal notation (e.g. `0755`). Required by `chmod`;
+	// defaults to `0750` for
+	// `mkdir`.
+	//
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode,omitempty"`
+
+	// The path. Glob patterns (e.g. `build/*.log`) are supported by `remove` and
+	// `chmod`.
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+}
+
+// A source and destination path of a filesystem operation.
+type FsTransfer struct {
+	// The destination path.
+	Dst string `json:"dst" yaml:"dst" mapstructure:"dst"`
+
+	// The source path.
+	Src string `json:"src" yaml:"src" mapstructure:"src"`
+}
+
 // Launches an application or opens a URI.
 type LaunchExecutableType struct {
 	// The application to launch the URI with.
 	App string `json:"app,omitempty" yaml:"app,omitempty" mapstructure:"app,omitempty"`
 
+	// Arguments to pass to the launched application. Environment variables, including
+	// parameters and
+	// arguments, are expanded. The `app` field must be set when arguments are
+	// provided.
+	//
+	AppArgs []string `json:"appArgs,omitempty" yaml:"appArgs,omitempty" mapstructure:"appArgs,omitempty"`
+
 	// Args corresponds to the JSON schema field "args".
 	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
@@ -178,10 +305,17 @@ type LaunchExecutableType struct {
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
 
 	// The URI to launch. This can be a file path or a web URL.
+	//
+	// When there is no display or opener (`xdg-open`) available, the URI is printed
+	// and copied to the
+	// clipboard instead of being opened.
+	//
 	URI string `json:"uri" yaml:"uri" mapstructure:"uri"`
 
 	// If set to true, the executable will wait for the launched application to exit
 	// before continuing.
+	// The executable fails if the application exits with a non-zero status.
+	//
 	Wait bool `json:"wait,omitempty" yaml:"wait,omitempty" mapstructure:"wait,omitempty"`
 }
 
@@ -219,6 +353,12 @@ type ParallelRefConfig struct {
 	//
 	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
 
+	// The name of a steps fragment defined in the flow file, or in one of its
+	// imports. The exec is replaced with
+	// the steps of the fragment and all other fields are ignored.
+	//
+	Fragment string `json:"fragment,omitempty" yaml:"fragment,omitempty" mapstructure:"fragment,omitempty"`
+
 	// An expression that determines whether the executable should run, using the Expr
 	// language syntax.
 	// The expression is evaluated at runtime and must resolve to a boolean value.
@@ -252,9 +392,16 @@ type ParallelRefConfigList []ParallelRefConfig
 // sub-executables.
 // Only one of `text`, `secretRef`, or `prompt` must be set. Specifying more than
 // one will result in an error.
+// The `envKey` is required unless the parameter is a `fragment`.
 type Parameter struct {
 	// The name of the environment variable that will be assigned the value.
-	EnvKey string `json:"envKey" yaml:"envKey" mapstructure:"envKey"`
+	EnvKey string `json:"envKey,omitempty" yaml:"envKey,omitempty" mapstructure:"envKey,omitempty"`
+
+	// The name of a params fragment defined in the flow file, or in one of its
+	// imports. The parameter is replaced
+	// with the parameters of the fragment and all other fields are ignored.
+	//
+	Fragment string `json:"fragment,omitempty" yaml:"fragment,omitempty" mapstructure:"fragment,omitempty"`
 
 	// A prompt to be displayed to the user when collecting an input value.
 	Prompt string `json:"prompt,omitempty" yaml:"prompt,omitempty" mapstructure:"prompt,omitempty"`
@@ -268,6 +415,95 @@ type Parameter struct {
 
 type ParameterList []Parameter
 
+// A form that is displayed between the steps of a serial executable. The answers
+// are set as environment
+// variables and store values for the steps that follow.
+type PromptConfig struct {
+	// The questions to ask.
+	Fields PromptFieldList `json:"fields" yaml:"fields" mapstructure:"fields"`
+
+	// The maximum amount of time to wait for answers, in Go duration format (e.g.
+	// 30s, 5m). When the timeout is
+	// reached, the default answers are used.
+	//
+	//
+	Timeout *time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+}
+
+// A question that is asked by a prompt step.
+type PromptField struct {
+	// The answer used if none is given. For `multiselect` questions, this is a
+	// comma-separated list of options.
+	//
+	Default string `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
+
+	// Additional text to display with the question.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+
+	// The name of the environment variable and store key that the answer is saved to.
+	//
+	Key string `json:"key" yaml:"key" mapstructure:"key"`
+
+	// The options that can be selected. Required for `select` and `multiselect`
+	// questions.
+	Options []string `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+
+	// If set to true, an answer must be given.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty" mapstructure:"required,omitempty"`
+
+	// The question to display. The key is displayed if a title is not set.
+	Title string `json:"title,omitempty" yaml:"title,omitempty" mapstructure:"title,omitempty"`
+
+	// The type of input to display. Answers to `multiselect` questions are saved as a
+	// comma-separated list and
+	// answers to `confirm` questions are saved as `true` or `false`.
+	//
+	Type PromptFieldType `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type,omitempty"`
+
+	// A regular expression that text answers must match.
+	Validation string `json:"validation,omitempty" yaml:"validation,omitempty" mapstructure:"validation,omitempty"`
+}
+
+type PromptFieldList []PromptField
+
+type PromptFieldType string
+
+const PromptFieldTypeConfirm PromptFieldType = "confirm"
+const PromptFieldTypeMasked PromptFieldType = "masked"
+const PromptFieldTypeMultiselect PromptFieldType = "multiselect"
+const PromptFieldTypeSelect PromptFieldType = "select"
+const PromptFieldTypeText PromptFieldType = "text"
+
+// A check that determines when a service is ready. Only one of `http`, `tcp`,
+// `log`, or `cmd` must be set.
+type ReadinessProbe struct {
+	// A command that exits with a status of 0 when the service is ready.
+	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
+
+	// A URL that returns the expected `status` when the service is ready.
+	Http string `json:"http,omitempty" yaml:"http,omitempty" mapstructure:"http,omitempty"`
+
+	// The amount of time to wait between checks, in Go duration format (e.g. 500ms,
+	// 1s).
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
+
+	// A regular expression that matches a line of the service's output when it is
+	// ready.
+	Log string `json:"log,omitempty" yaml:"log,omitempty" mapstructure:"log,omitempty"`
+
+	// The HTTP status code that the `http` URL must return.
+	Status int `json:"status,omitempty" yaml:"status,omitempty" mapstructure:"status,omitempty"`
+
+	// An address (e.g. `localhost:5432`) that accepts connections when the service is
+	// ready.
+	Tcp string `json:"tcp,omitempty" yaml:"tcp,omitempty" mapstructure:"tcp,omitempty"`
+
+	// The maximum amount of time to wait for the service to be ready, in Go duration
+	// format (e.g. 30s, 2m).
+	//
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+}
+
 // A reference to an executable.
 // The format is `<verb> <workspace>/<namespace>:<executable name>`.
 // For example, `exec ws/ns:my-workflow`.
@@ -279,22 +515,159 @@ type Ref string
 
 type RefList []Ref
 
+// An executable that provides data to a render template.
+type RenderDataSource struct {
+	// Arguments to pass to the executable.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// The key that the output is added to under `.data` in the template.
+	Key string `json:"key" yaml:"key" mapstructure:"key"`
+
+	// The reference to the executable. The response body is used for request
+	// executables.
+	Ref Ref `json:"ref" yaml:"ref" mapstructure:"ref"`
+}
+
+type RenderDataSourceList []RenderDataSource
+
 // Renders a markdown template file with data.
 type RenderExecutableType struct {
 	// Args corresponds to the JSON schema field "args".
 	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
+	// Inline template data. It is deep-merged after the data files.
+	Data RenderExecutableTypeData `json:"data,omitempty" yaml:"data,omitempty" mapstructure:"data,omitempty"`
+
+	// Executables to run before rendering. The output of each executable (or the
+	// response body of a request
+	// executable) is added to the template data under its key. JSON and YAML output
+	// is parsed.
+	//
+	DataFrom RenderDataSourceList `json:"dataFrom,omitempty" yaml:"dataFrom,omitempty" mapstructure:"dataFrom,omitempty"`
+
 	// Dir corresponds to the JSON schema field "dir".
 	Dir Directory `json:"dir,omitempty" yaml:"dir,omitempty" mapstructure:"dir,omitempty"`
 
+	// Where the rendered content is displayed or written.
+	// - `tui`: Display the markdown in the interactive terminal UI. Falls back to
+	// `stdout` when the
+	//   interactive UI is disabled (e.g. with `flow exec --no-tui`).
+	// - `stdout`: Print the styled markdown to the terminal.
+	// - `file`: Write the raw rendered markdown to the `outputFile`.
+	// - `html`: Convert the rendered markdown to HTML and write it to the
+	// `outputFile`.
+	//
+	Output RenderExecutableTypeOutput `json:"output,omitempty" yaml:"output,omitempty" mapstructure:"output,omitempty"`
+
+	// The path to write the rendered content to. Required when the `output` is `file`
+	// or `html`.
+	// Relative paths are resolved from the render's `dir`.
+	//
+	OutputFile string `json:"outputFile,omitempty" yaml:"outputFile,omitempty" mapstructure:"outputFile,omitempty"`
+
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
 
+	// How the rendered content is displayed. The content of the template file is
+	// treated as markdown by default.
+	// Use `json`, `yaml` or `table` to browse data files (e.g. a JSON API dump or a
+	// CSV report).
+	// CSV and TSV content is detected from the `.csv` and `.tsv` template file
+	// extensions.
+	//
+	RenderAs ViewFormat `json:"renderAs,omitempty" yaml:"renderAs,omitempty" mapstructure:"renderAs,omitempty"`
+
 	// The path to the JSON or YAML file containing the template data.
 	TemplateDataFile string `json:"templateDataFile,omitempty" yaml:"templateDataFile,omitempty" mapstructure:"templateDataFile,omitempty"`
 
+	// Paths to JSON or YAML files containing template data. The files are deep-merged
+	// in order after the
+	// `templateDataFile`, so values in later files take precedence.
+	//
+	TemplateDataFiles []string `json:"templateDataFiles,omitempty" yaml:"templateDataFiles,omitempty" mapstructure:"templateDataFiles,omitempty"`
+
 	// The path to the markdown template file to render.
 	TemplateFile string `json:"templateFile" yaml:"templateFile" mapstructure:"templateFile"`
+
+	// Watch the template and data files and re-render the content when they change.
+	// Template errors are
+	// displayed in place of the content until they are fixed. This can also be
+	// enabled with `flow exec --watch`.
+	//
+	Watch bool `json:"watch,omitempty" yaml:"watch,omitempty" mapstructure:"watch,omitempty"`
+}
+
+// Inline template data. It is deep-merged after the data files.
+type RenderExecutableTypeData map[string]interface{}
+
+type RenderExecutableTypeOutput string
+
+const RenderExecutableTypeOutputFile RenderExecutableTypeOutput = "file"
+const RenderExecutableTypeOutputHtml RenderExecutableTypeOutput = "html"
+const RenderExecutableTypeOutputStdout RenderExecutableTypeOutput = "stdout"
+const RenderExecutableTypeOutputTui RenderExecutableTypeOutput = "tui"
+
+// API key authentication. The key is sent as a header or query parameter.
+// Only one of `value` or `secretRef` must be set.
+type RequestAPIKeyAuth struct {
+	// Where the API key should be sent.
+	In RequestAPIKeyAuthIn `json:"in,omitempty" yaml:"in,omitempty" mapstructure:"in,omitempty"`
+
+	// The name of the header or query parameter to set.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// A reference to the secret containing the API key.
+	SecretRef string `json:"secretRef,omitempty" yaml:"secretRef,omitempty" mapstructure:"secretRef,omitempty"`
+
+	// The API key value. Environment variables in the value will be expanded at
+	// runtime.
+	Value string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+type RequestAPIKeyAuthIn string
+
+const RequestAPIKeyAuthInHeader RequestAPIKeyAuthIn = "header"
+const RequestAPIKeyAuthInQuery RequestAPIKeyAuthIn = "query"
+
+// Authentication to apply to the request.
+// Only one of `basic`, `bearer`, `apiKey`, or `oauth2ClientCredentials` must be
+// set.
+type RequestAuth struct {
+	// ApiKey corresponds to the JSON schema field "apiKey".
+	ApiKey *RequestAPIKeyAuth `json:"apiKey,omitempty" yaml:"apiKey,omitempty" mapstructure:"apiKey,omitempty"`
+
+	// Basic corresponds to the JSON schema field "basic".
+	Basic *RequestBasicAuth `json:"basic,omitempty" yaml:"basic,omitempty" mapstructure:"basic,omitempty"`
+
+	// Bearer corresponds to the JSON schema field "bearer".
+	Bearer *RequestBearerAuth `json:"bearer,omitempty" yaml:"bearer,omitempty" mapstructure:"bearer,omitempty"`
+
+	// Oauth2ClientCredentials corresponds to the JSON schema field
+	// "oauth2ClientCredentials".
+	Oauth2ClientCredentials *RequestOAuth2ClientCredentials `json:"oauth2ClientCredentials,omitempty" yaml:"oauth2ClientCredentials,omitempty" mapstructure:"oauth2ClientCredentials,omitempty"`
+}
+
+// HTTP basic authentication credentials.
+type RequestBasicAuth struct {
+	// A reference to the secret containing the password.
+	SecretRef string `json:"secretRef" yaml:"secretRef" mapstructure:"secretRef"`
+
+	// The username to authenticate with. Environment variables in the value will be
+	// expanded at runtime.
+	Username string `json:"username" yaml:"username" mapstructure:"username"`
+}
+
+// Bearer token authentication. The token is sent in the `Authorization` header.
+// Only one of `token` or `secretRef` must be set.
+type RequestBearerAuth struct {
+	// A reference to the secret containing the token.
+	SecretRef string `json:"secretRef,omitempty" yaml:"secretRef,omitempty" mapstructure:"secretRef,omitempty"`
+
+	// The token to send. This is typically set to an environment variable resolved
+	// from a param or arg
+	// (e.g. `$API_TOKEN`).
+	//
+	Token string `json:"token,omitempty" yaml:"token,omitempty" mapstructure:"token,omitempty"`
 }
 
 // Makes an HTTP request.
@@ -302,25 +675,91 @@ type RequestExecutableType struct {
 	// Args corresponds to the JSON schema field "args".
 	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
+	// A list of expressions, using the Expr language syntax, that must evaluate to
+	// true for the executable to
+	// succeed. The expressions have access to the response `status`, `headers`,
+	// `body` (parsed as JSON when
+	// possible), and `duration` (in milliseconds), along with the executable's
+	// environment variables (`env`).
+	//
+	// For example, `status == 200 && len(body.items) > 0` or `duration < 500`.
+	//
+	Assert []string `json:"assert,omitempty" yaml:"assert,omitempty" mapstructure:"assert,omitempty"`
+
+	// Auth corresponds to the JSON schema field "auth".
+	Auth *RequestAuth `json:"auth,omitempty" yaml:"auth,omitempty" mapstructure:"auth,omitempty"`
+
 	// The body of the request.
+	// Only one of `body`, `form`, `multipart`, `bodyFile`, `json`, or `graphql` can
+	// be set.
+	//
 	Body string `json:"body,omitempty" yaml:"body,omitempty" mapstructure:"body,omitempty"`
 
+	// The path to a file to stream as the request body. Relative paths are resolved
+	// from the executable's directory.
+	// The `Content-Type` header is set based on the file extension if not already
+	// set.
+	//
+	BodyFile string `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty" mapstructure:"bodyFile,omitempty"`
+
+	// A list of rules for extracting values from the response into the process store.
+	// Values that are not strings are saved as JSON.
+	//
+	//
+	Extract RequestExtractList `json:"extract,omitempty" yaml:"extract,omitempty" mapstructure:"extract,omitempty"`
+
+	// A map of fields to send as a URL encoded form body.
+	// The `Content-Type` header is set to `application/x-www-form-urlencoded` if not
+	// already set.
+	//
+	Form RequestExecutableTypeForm `json:"form,omitempty" yaml:"form,omitempty" mapstructure:"form,omitempty"`
+
+	// GraphQL corresponds to the JSON schema field "graphql".
+	GraphQL *RequestGraphQL `json:"graphql,omitempty" yaml:"graphql,omitempty" mapstructure:"graphql,omitempty"`
+
 	// A map of headers to include in the request.
 	Headers RequestExecutableTypeHeaders `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
 
+	// Configuration for the HTTP client used to send the request.
+	// Fields that are set override the `httpClient` fields set in the user config.
+	//
+	HttpClient *RequestExecutableTypeHttpClient `json:"httpClient,omitempty" yaml:"httpClient,omitempty" mapstructure:"httpClient,omitempty"`
+
+	// A structured value that is marshaled to JSON and sent as the request body.
+	// The `Content-Type` header is set to `application/json` if not already set.
+	//
+	JSON interface{} `json:"json,omitempty" yaml:"json,omitempty" mapstructure:"json,omitempty"`
+
 	// If set to true, the response will be logged as program output.
 	LogResponse bool `json:"logResponse,omitempty" yaml:"logResponse,omitempty" mapstructure:"logResponse,omitempty"`
 
 	// The HTTP method to use when making the request.
 	Method RequestExecutableTypeMethod `json:"method,omitempty" yaml:"method,omitempty" mapstructure:"method,omitempty"`
 
+	// A list of fields and files to send as a multipart form body.
+	// The `Content-Type` header is set to `multipart/form-data` if not already set.
+	//
+	Multipart RequestMultipartPartList `json:"multipart,omitempty" yaml:"multipart,omitempty" mapstructure:"multipart,omitempty"`
+
+	// Paginate corresponds to the JSON schema field "paginate".
+	Paginate *RequestPaginate `json:"paginate,omitempty" yaml:"paginate,omitempty" mapstructure:"paginate,omitempty"`
+
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
 
+	// A map of query parameters to add to the request URL.
+	Query RequestExecutableTypeQuery `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query,omitempty"`
+
 	// ResponseFile corresponds to the JSON schema field "responseFile".
 	ResponseFile *RequestResponseFile `json:"responseFile,omitempty" yaml:"responseFile,omitempty" mapstructure:"responseFile,omitempty"`
 
-	// The timeout for the request in Go duration format (e.g. 30s, 5m, 1h).
+	// Retry corresponds to the JSON schema field "retry".
+	Retry *RequestRetry `json:"retry,omitempty" yaml:"retry,omitempty" mapstructure:"retry,omitempty"`
+
+	// The timeout for the request in Go duration format (e.g. 30s, 5m, 1h). When the
+	// response is streamed to
+	// a file, the timeout only applies until the response headers are received.
+	//
 	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 
 	// JQ query to transform the response before saving it to a file or outputting it.
@@ -334,11 +773,26 @@ type RequestExecutableType struct {
 	// If not set, the response status code will not be checked.
 	//
 	ValidStatusCodes []int `json:"validStatusCodes,omitempty" yaml:"validStatusCodes,omitempty" mapstructure:"validStatusCodes,omitempty"`
+
+	// Open the response in a viewer after the request completes. The response is
+	// printed to the terminal
+	// when the interactive UI is disabled.
+	//
+	ViewResponse ViewFormat `json:"viewResponse,omitempty" yaml:"viewResponse,omitempty" mapstructure:"viewResponse,omitempty"`
 }
 
+// A map of fields to send as a URL encoded form body.
+// The `Content-Type` header is set to `application/x-www-form-urlencoded` if not
+// already set.
+type RequestExecutableTypeForm map[string]string
+
 // A map of headers to include in the request.
 type RequestExecutableTypeHeaders map[string]string
 
+// Configuration for the HTTP client used to send the request.
+// Fields that are set override the `httpClient` fields set in the user config.
+type RequestExecutableTypeHttpClient common.HTTPClientConfig
+
 type RequestExecutableTypeMethod string
 
 const RequestExecutableTypeMethodDELETE RequestExecutableTypeMethod = "DELETE"
@@ -347,6 +801,141 @@ const RequestExecutableTypeMethodPATCH RequestExecutableTypeMethod = "PATCH"
 const RequestExecutableTypeMethodPOST RequestExecutableTypeMethod = "POST"
 const RequestExecutableTypeMethodPUT RequestExecutableTypeMethod = "PUT"
 
+// A map of query parameters to add to the request URL.
+type RequestExecutableTypeQuery map[string]string
+
+// A rule for extracting a value from the response and saving it to the process
+// store.
+// Only one of `jq` or `expr` must be set.
+type RequestExtract struct {
+	// An expression evaluated against the response using the Expr language syntax.
+	// The expression has access to the same data as `assert` expressions.
+	//
+	Expr string `json:"expr,omitempty" yaml:"expr,omitempty" mapstructure:"expr,omitempty"`
+
+	// A JQ query evaluated against the response body, which must be valid JSON.
+	Jq string `json:"jq,omitempty" yaml:"jq,omitempty" mapstructure:"jq,omitempty"`
+
+	// The key to save the extracted value under in the process store.
+	Key string `json:"key" yaml:"key" mapstructure:"key"`
+}
+
+type RequestExtractList []RequestExtract
+
+// A GraphQL operation to send as the request. The request method defaults to
+// `POST`; if it is set to `GET`,
+// the operation is sent as query parameters. Only one of `query` or `queryFile`
+// must be set.
+//
+// The executable fails if the response contains a non-empty `errors` list.
+type RequestGraphQL struct {
+	// The name of the operation to run when the document contains multiple
+	// operations.
+	OperationName string `json:"operationName,omitempty" yaml:"operationName,omitempty" mapstructure:"operationName,omitempty"`
+
+	// The GraphQL query or mutation document.
+	Query string `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query,omitempty"`
+
+	// The path to a file containing the GraphQL document. Relative paths are resolved
+	// from the executable's
+	// directory.
+	//
+	QueryFile string `json:"queryFile,omitempty" yaml:"queryFile,omitempty" mapstructure:"queryFile,omitempty"`
+
+	// A map of variables for the operation. Environment variables are expanded in all
+	// string values.
+	Variables RequestGraphQLVariables `json:"variables,omitempty" yaml:"variables,omitempty" mapstructure:"variables,omitempty"`
+}
+
+// A map of variables for the operation. Environment variables are expanded in all
+// string values.
+type RequestGraphQLVariables map[string]interface{}
+
+// A part of a multipart form body.
+// Only one of `value` or `file` must be set.
+type RequestMultipartPart struct {
+	// The content type of the part. Defaults to a type detected from the file
+	// extension.
+	ContentType string `json:"contentType,omitempty" yaml:"contentType,omitempty" mapstructure:"contentType,omitempty"`
+
+	// The path to a file to upload as the field's content. Relative paths are
+	// resolved from the executable's
+	// directory.
+	//
+	File string `json:"file,omitempty" yaml:"file,omitempty" mapstructure:"file,omitempty"`
+
+	// The filename to send for the file. Defaults to the base name of `file`.
+	Filename string `json:"filename,omitempty" yaml:"filename,omitempty" mapstructure:"filename,omitempty"`
+
+	// The name of the form field.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// The value of the form field.
+	Value string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+type RequestMultipartPartList []RequestMultipartPart
+
+// OAuth2 client credentials grant. An access token is requested from the token URL
+// and cached in the
+// flow store until it expires. If the request is rejected with a 401 status code,
+// the token is refreshed
+// and the request is sent again.
+type RequestOAuth2ClientCredentials struct {
+	// The client ID. Environment variables in the value will be expanded at runtime.
+	ClientID string `json:"clientID" yaml:"clientID" mapstructure:"clientID"`
+
+	// A reference to the secret containing the client secret.
+	ClientSecretRef string `json:"clientSecretRef" yaml:"clientSecretRef" mapstructure:"clientSecretRef"`
+
+	// The scopes to request.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty" mapstructure:"scopes,omitempty"`
+
+	// The URL of the token endpoint.
+	TokenURL string `json:"tokenURL" yaml:"tokenURL" mapstructure:"tokenURL"`
+}
+
+// Configuration for following paginated responses. The items of each page are
+// concatenated into a single
+// JSON array before the response is transformed or saved.
+type RequestPaginate struct {
+	// A JQ query for the cursor of the next page. Required for the `cursor` strategy.
+	// Pagination stops when the cursor is empty or null.
+	//
+	Cursor string `json:"cursor,omitempty" yaml:"cursor,omitempty" mapstructure:"cursor,omitempty"`
+
+	// The query parameter used to send the cursor.
+	CursorParam string `json:"cursorParam,omitempty" yaml:"cursorParam,omitempty" mapstructure:"cursorParam,omitempty"`
+
+	// A JQ query for the list of items in each page. If not set, the response body
+	// must be a list.
+	//
+	Items string `json:"items,omitempty" yaml:"items,omitempty" mapstructure:"items,omitempty"`
+
+	// The maximum number of pages to request. Defaults to 100.
+	MaxPages int `json:"maxPages,omitempty" yaml:"maxPages,omitempty" mapstructure:"maxPages,omitempty"`
+
+	// The query parameter used to send the page number.
+	PageParam string `json:"pageParam,omitempty" yaml:"pageParam,omitempty" mapstructure:"pageParam,omitempty"`
+
+	// The number of the first page. Defaults to 1.
+	StartPage int `json:"startPage,omitempty" yaml:"startPage,omitempty" mapstructure:"startPage,omitempty"`
+
+	// The pagination strategy used by the API.
+	// `linkHeader` follows the `next` URL of the `Link` response header.
+	// `cursor` sends the value found at the `cursor` path as the `cursorParam` query
+	// parameter.
+	// `page` increments the `pageParam` query parameter until a page has no items.
+	//
+	Strategy RequestPaginateStrategy `json:"strategy" yaml:"strategy" mapstructure:"strategy"`
+}
+
+type RequestPaginateStrategy string
+
+const RequestPaginateStrategyCursor RequestPaginateStrategy = "cursor"
+const RequestPaginateStrategyLinkHeader RequestPaginateStrategy = "linkHeader"
+const RequestPaginateStrategyPage RequestPaginateStrategy = "page"
+
 // Configuration for saving the response of a request to a file.
 type RequestResponseFile struct {
 	// Dir corresponds to the JSON schema field "dir".
@@ -356,7 +945,20 @@ type RequestResponseFile struct {
 	Filename string `json:"filename" yaml:"filename" mapstructure:"filename"`
 
 	// The format to save the response as.
+	//
+	// When saved as `raw` and the response body is not used by `logResponse`,
+	// `transformResponse`, `paginate`,
+	// `assert`, or `extract`, the response is streamed to the file. Streamed
+	// downloads show their progress and
+	// are resumed with a `Range` request if a previous download was interrupted and
+	// the server supports it.
+	//
 	SaveAs RequestResponseFileSaveAs `json:"saveAs,omitempty" yaml:"saveAs,omitempty" mapstructure:"saveAs,omitempty"`
+
+	// The expected SHA-256 checksum of the saved file, as a hex string.
+	// If the checksum does not match, the file is removed and the executable fails.
+	//
+	Sha256 string `json:"sha256,omitempty" yaml:"sha256,omitempty" mapstructure:"sha256,omitempty"`
 }
 
 type RequestResponseFileSaveAs string
@@ -367,6 +969,28 @@ const RequestResponseFileSaveAsRaw RequestResponseFileSaveAs = "raw"
 const RequestResponseFileSaveAsYaml RequestResponseFileSaveAs = "yaml"
 const RequestResponseFileSaveAsYml RequestResponseFileSaveAs = "yml"
 
+// Configuration for retrying the request when the server responds with a retryable
+// status code.
+// The delay between attempts grows exponentially from `backoff` up to
+// `maxBackoff`. If the response includes
+// a `Retry-After` header, its value is used as the delay instead, up to
+// `maxBackoff`.
+type RequestRetry struct {
+	// The maximum number of times the request is sent, including the first attempt.
+	Attempts int `json:"attempts,omitempty" yaml:"attempts,omitempty" mapstructure:"attempts,omitempty"`
+
+	// The delay before the first retry in Go duration format (e.g. 500ms, 1s).
+	// Defaults to 1s.
+	Backoff time.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty" mapstructure:"backoff,omitempty"`
+
+	// The maximum delay between retries in Go duration format. Defaults to 30s.
+	MaxBackoff time.Duration `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty" mapstructure:"maxBackoff,omitempty"`
+
+	// The response status codes that should be retried. Defaults to 429, 502, 503,
+	// and 504.
+	StatusCodes []int `json:"statusCodes,omitempty" yaml:"statusCodes,omitempty" mapstructure:"statusCodes,omitempty"`
+}
+
 // Executes a list of executables in serial.
 type SerialExecutableType struct {
 	// Args corresponds to the JSON schema field "args".
@@ -395,10 +1019,16 @@ type SerialRefConfig struct {
 	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
 	// The command to execute.
-	// One of `cmd` or `ref` must be set.
+	// One of `cmd`, `ref`, `prompt`, or `wait` must be set.
 	//
 	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
 
+	// The name of a steps fragment defined in the flow file, or in one of its
+	// imports. The exec is replaced with
+	// the steps of the fragment and all other fields are ignored.
+	//
+	Fragment string `json:"fragment,omitempty" yaml:"fragment,omitempty" mapstructure:"fragment,omitempty"`
+
 	// An expression that determines whether the executable should run, using the Expr
 	// language syntax.
 	// The expression is evaluated at runtime and must resolve to a boolean value.
@@ -415,8 +1045,14 @@ type SerialRefConfig struct {
 	//
 	If string `json:"if,omitempty" yaml:"if,omitempty" mapstructure:"if,omitempty"`
 
+	// A form to display to the user. The answers are available to the steps that
+	// follow.
+	// One of `cmd`, `ref`, `prompt`, or `wait` must be set.
+	//
+	Prompt *PromptConfig `json:"prompt,omitempty" yaml:"prompt,omitempty" mapstructure:"prompt,omitempty"`
+
 	// A reference to another executable to run in serial.
-	// One of `cmd` or `ref` must be set.
+	// One of `cmd`, `ref`, `prompt`, or `wait` must be set.
 	//
 	Ref Ref `json:"ref,omitempty" yaml:"ref,omitempty" mapstructure:"ref,omitempty"`
 
@@ -426,12 +1062,52 @@ type SerialRefConfig struct {
 	// If set to true, the user will be prompted to review the output of the
 	// executable before continuing.
 	ReviewRequired bool `json:"reviewRequired,omitempty" yaml:"reviewRequired,omitempty" mapstructure:"reviewRequired,omitempty"`
+
+	// A condition to wait for before running the steps that follow.
+	// One of `cmd`, `ref`, `prompt`, or `wait` must be set.
+	//
+	Wait *WaitExecutableType `json:"wait,omitempty" yaml:"wait,omitempty" mapstructure:"wait,omitempty"`
 }
 
 // A list of executables to run in serial. The executables can be defined by it's
 // exec `cmd` or `ref`.
 type SerialRefConfigList []SerialRefConfig
 
+// Starts a long-running command in the background. The executable completes once
+// the service is ready.
+// When run as part of a serial or parallel executable, the service is stopped when
+// the parent executable finishes.
+type ServiceExecutableType struct {
+	// Args corresponds to the JSON schema field "args".
+	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// The command that starts the service.
+	Cmd string `json:"cmd" yaml:"cmd" mapstructure:"cmd"`
+
+	// Dir corresponds to the JSON schema field "dir".
+	Dir Directory `json:"dir,omitempty" yaml:"dir,omitempty" mapstructure:"dir,omitempty"`
+
+	// If set to true, the service is not stopped when the parent serial or parallel
+	// executable finishes.
+	// Use `flow service stop` to stop it.
+	//
+	KeepRunning bool `json:"keepRunning,omitempty" yaml:"keepRunning,omitempty" mapstructure:"keepRunning,omitempty"`
+
+	// Params corresponds to the JSON schema field "params".
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// The check that determines when the service is ready. If not set, the service is
+	// ready once it has started.
+	//
+	Ready *ReadinessProbe `json:"ready,omitempty" yaml:"ready,omitempty" mapstructure:"ready,omitempty"`
+
+	// The amount of time to wait for the service to exit after it is asked to stop
+	// before it is killed, in Go
+	// duration format (e.g. 10s).
+	//
+	StopTimeout time.Duration `json:"stopTimeout,omitempty" yaml:"stopTimeout,omitempty" mapstructure:"stopTimeout,omitempty"`
+}
+
 type Verb string
 
 const VerbAbort Verb = "abort"
@@ -510,3 +1186,97 @@ const VerbValidate Verb = "validate"
 const VerbVerify Verb = "verify"
 const VerbView Verb = "view"
 const VerbWatch Verb = "watch"
+
+type ViewFormat string
+
+const ViewFormatJson ViewFormat = "json"
+const ViewFormatMarkdown ViewFormat = "markdown"
+const ViewFormatTable ViewFormat = "table"
+const ViewFormatYaml ViewFormat = "yaml"
+
+// Waits until a condition is met. Only one of `http`, `tcp`, `file`, `cmd`, or
+// `expr` must be set.
+// The condition is checked every `interval` and the executable fails if it is not
+// met before the `timeout`.
+type WaitExecutableType struct {
+	// If set to true, the `file` condition is met when the file does not exist.
+	Absent bool `json:"absent,omitempty" yaml:"absent,omitempty" mapstructure:"absent,omitempty"`
+
+	// Args corresponds to the JSON schema field "args".
+	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// A command that must exit with a status of 0.
+	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
+
+	// Dir corresponds to the JSON schema field "dir".
+	Dir Directory `json:"dir,omitempty" yaml:"dir,omitempty" mapstructure:"dir,omitempty"`
+
+	// An expression that must resolve to true, using the Expr language syntax. The
+	// expression has access to the
+	// same data as the `if` field of serial executables; the stored data (store) is
+	// read again before each check.
+	//
+	Expr string `json:"expr,omitempty" yaml:"expr,omitempty" mapstructure:"expr,omitempty"`
+
+	// A path to a file that must exist. Set `absent` to wait for the file to be
+	// removed instead.
+	File string `json:"file,omitempty" yaml:"file,omitempty" mapstructure:"file,omitempty"`
+
+	// A URL that must return the expected `status`.
+	Http string `json:"http,omitempty" yaml:"http,omitempty" mapstructure:"http,omitempty"`
+
+	// The amount of time to wait between checks, in Go duration format (e.g. 500ms,
+	// 2s).
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
+
+	// The message displayed while waiting. Defaults to a description of the
+	// condition.
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message,omitempty"`
+
+	// Params corresponds to the JSON schema field "params".
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// The HTTP status code that the `http` URL must return.
+	Status int `json:"status,omitempty" yaml:"status,omitempty" mapstructure:"status,omitempty"`
+
+	// An address (e.g. `localhost:5432`) that must accept connections.
+	Tcp string `json:"tcp,omitempty" yaml:"tcp,omitempty" mapstructure:"tcp,omitempty"`
+
+	// The maximum amount of time to wait, in Go duration format (e.g. 30s, 5m).
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+}
+
+// Configuration for re-running the executable when files change. Watch mode is
+// started with the
+// `--watch` flag of the `flow exec` command.
+type WatchConfig struct {
+	// If set to true, the screen is cleared before each run.
+	Clear bool `json:"clear,omitempty" yaml:"clear,omitempty" mapstructure:"clear,omitempty"`
+
+	// The amount of time to wait for changes to settle before re-running the
+	// executable, in Go duration
+	// format (e.g. 500ms, 2s).
+	//
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty" mapstructure:"debounce,omitempty"`
+
+	// Glob patterns for files and directories that should not be watched. Patterns
+	// without a `/` are
+	// matched against each element of the path (e.g. `node_modules` or `*.log`).
+	// The `.git` directory and the workspace's excluded paths are always ignored.
+	//
+	Ignore []string `json:"ignore,omitempty" yaml:"ignore,omitempty" mapstructure:"ignore,omitempty"`
+
+	// Glob patterns for the files to watch. Paths are relative to the flowfile
+	// directory unless they start
+	// with `//` (the workspace root), `~/` or `/`. The `**` pattern matches any
+	// number of directories.
+	// When empty, all files in the flowfile directory are watched.
+	//
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty" mapstructure:"paths,omitempty"`
+
+	// If set to true, a run that is still in progress is stopped before the
+	// executable is re-run.
+	// This is useful for long-running processes like development servers.
+	//
+	Restart bool `json:"restart,omitempty" yaml:"restart,omitempty" mapstructure:"restart,omitempty"`
+}
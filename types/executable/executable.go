@@ -14,6 +14,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/jahvon/flow/internal/errors"
+	"github.com/jahvon/flow/internal/services/cron"
 	"github.com/jahvon/flow/internal/utils"
 	"github.com/jahvon/flow/types/common"
 )
@@ -50,9 +51,16 @@ func (e Directory) ExpandDirectory(
 type ExecutableEnvironment struct {
 	Params ParameterList `json:"params" yaml:"params"`
 	Args   ArgumentList  `json:"args"   yaml:"args"`
+
+	// sources maps the env key of each param to the ParamSource that defines it. It is only set when the executable
+	// inherits params.
+	sources map[string]ParamSource
 }
 
 func (e *ExecExecutableType) SetLogFields(fields map[string]interface{}) {
+	if e == nil {
+		return
+	}
 	e.logFields = fields
 }
 
@@ -64,8 +72,9 @@ type enrichedExecutableList struct {
 	Executables []*enrichedExecutable `json:"executables" yaml:"executables"`
 }
 type enrichedExecutable struct {
-	ID   string      `json:"id"   yaml:"id"`
-	Spec *Executable `json:"spec" yaml:"spec"`
+	ID              string        `json:"id"                        yaml:"id"`
+	Spec            *Executable   `json:"spec"                      yaml:"spec"`
+	InheritedParams ParameterList `json:"inheritedParams,omitempty" yaml:"inheritedParams,omitempty"`
 }
 
 func (e *Executable) SetContext(workspaceName, workspacePath, namespace, flowFilePath string) {
@@ -91,12 +100,74 @@ func (e *Executable) SetInheritedFields(flowFile *FlowFile) {
 		}
 	}
 	e.inheritedDescription = strings.Join([]string{flowFile.Description, descFromFIle}, "\n")
+	e.flowFileParams = EnvParams(flowFile.Env, flowFile.Params)
+	e.workspaceParams = flowFile.workspaceParams
+}
+
+// InheritedParams returns the flow file and workspace params that apply to the executable. Params with the same env
+// key as one of the executable's own params are not included.
+func (e *Executable) InheritedParams() ParameterList {
+	env := e.Env()
+	if env == nil {
+		return nil
+	}
+	var inherited ParameterList
+	for _, p := range env.Params {
+		if source, found := env.sources[p.EnvKey]; found && source != ParamSourceExecutable {
+			inherited = append(inherited, p)
+		}
+	}
+	return inherited
+}
+
+// Source returns where the param with the env key is defined.
+func (e *ExecutableEnvironment) Source(envKey string) ParamSource {
+	if source, found := e.sources[envKey]; found {
+		return source
+	}
+	return ParamSourceExecutable
+}
+
+// HasInheritedParams returns true if any of the params are defined by the flow file or workspace.
+func (e *ExecutableEnvironment) HasInheritedParams() bool {
+	for _, source := range e.sources {
+		if source != ParamSourceExecutable {
+			return true
+		}
+	}
+	return false
+}
+
+// inheritParams appends the flow file and workspace params to the params of the executable. The executable's params
+// take precedence over the flow file's, and the flow file's take precedence over the workspace's.
+func (e *Executable) inheritParams(params ParameterList) (ParameterList, map[string]ParamSource) {
+	if len(e.flowFileParams) == 0 && len(e.workspaceParams) == 0 {
+		return params, nil
+	}
+	merged := slices.Clone(params)
+	sources := make(map[string]ParamSource)
+	for _, p := range params {
+		sources[p.EnvKey] = ParamSourceExecutable
+	}
+	inherit := func(source ParamSource, inherited ParameterList) {
+		for _, p := range inherited {
+			if _, found := sources[p.EnvKey]; found {
+				continue
+			}
+			sources[p.EnvKey] = source
+			merged = append(merged, p)
+		}
+	}
+	inherit(ParamSourceFlowFile, e.flowFileParams)
+	inherit(ParamSourceWorkspace, e.workspaceParams)
+	return merged, sources
 }
 
 func (e *Executable) YAML() (string, error) {
 	enriched := &enrichedExecutable{
-		ID:   e.ID(),
-		Spec: e,
+		ID:              e.ID(),
+		Spec:            e,
+		InheritedParams: e.InheritedParams(),
 	}
 	yamlBytes, err := yaml.Marshal(enriched)
 	if err != nil {
@@ -107,8 +178,9 @@ func (e *Executable) YAML() (string, error) {
 
 func (e *Executable) JSON() (string, error) {
 	enriched := &enrichedExecutable{
-		ID:   e.ID(),
-		Spec: e,
+		ID:              e.ID(),
+		Spec:            e,
+		InheritedParams: e.InheritedParams(),
 	}
 	jsonBytes, err := json.MarshalIndent(enriched, "", "  ")
 	if err != nil {
@@ -133,6 +205,30 @@ func (e *Executable) ID() string {
 	return NewExecutableID(e.workspace, e.namespace, e.Name)
 }
 
+// Step is an exec of a serial or parallel executable. The ref is empty for execs that are not references.
+type Step struct {
+	Parallel bool
+	Ref      Ref
+	If       string
+	Retries  int
+}
+
+// Steps returns the execs of a serial or parallel executable in order.
+func (e *Executable) Steps() []Step {
+	var steps []Step
+	if e.Serial != nil {
+		for _, refConfig := range e.Serial.Execs {
+			steps = append(steps, Step{Ref: refConfig.Ref, If: refConfig.If, Retries: refConfig.Retries})
+		}
+	}
+	if e.Parallel != nil {
+		for _, refConfig := range e.Parallel.Execs {
+			steps = append(steps, Step{Parallel: true, Ref: refConfig.Ref, If: refConfig.If, Retries: refConfig.Retries})
+		}
+	}
+	return steps
+}
+
 func (e *Executable) Env() *ExecutableEnvironment {
 	typeFields := []any{
 		e.Exec,
@@ -141,6 +237,9 @@ func (e *Executable) Env() *ExecutableEnvironment {
 		e.Render,
 		e.Serial,
 		e.Parallel,
+		e.Service,
+		e.Wait,
+		e.Fs,
 	}
 	var execType any
 	for _, field := range typeFields {
@@ -171,6 +270,7 @@ func (e *Executable) Env() *ExecutableEnvironment {
 			}
 		}
 	}
+	execEnv.Params, execEnv.sources = e.inheritParams(execEnv.Params)
 	return execEnv
 }
 
@@ -249,10 +349,39 @@ func (e *Executable) Validate() error {
 		e.Render,
 		e.Serial,
 		e.Parallel,
+		e.Service,
+		e.Wait,
+		e.Fs,
 	)
 	if err != nil {
 		return err
 	}
+	if e.Schedule != "" {
+		if _, err := cron.Parse(e.Schedule); err != nil {
+			return err
+		}
+	}
+	if err := e.Launch.Validate(); err != nil {
+		return err
+	}
+	if err := e.Request.Validate(); err != nil {
+		return err
+	}
+	if err := e.Render.Validate(); err != nil {
+		return err
+	}
+	if err := e.Serial.Validate(); err != nil {
+		return err
+	}
+	if err := e.Service.Validate(); err != nil {
+		return err
+	}
+	if err := e.Wait.Validate(); err != nil {
+		return err
+	}
+	if err := e.Fs.Validate(); err != nil {
+		return err
+	}
 
 	if e.workspace == "" {
 		return fmt.Errorf("workspace was not set")
@@ -312,8 +441,9 @@ func (l ExecutableList) YAML() (string, error) {
 	enriched := &enrichedExecutableList{}
 	for _, exec := range l {
 		enriched.Executables = append(enriched.Executables, &enrichedExecutable{
-			ID:   exec.ID(),
-			Spec: exec,
+			ID:              exec.ID(),
+			Spec:            exec,
+			InheritedParams: exec.InheritedParams(),
 		})
 	}
 	yamlBytes, err := yaml.Marshal(enriched)
@@ -327,8 +457,9 @@ func (l ExecutableList) JSON() (string, error) {
 	enriched := &enrichedExecutableList{}
 	for _, exec := range l {
 		enriched.Executables = append(enriched.Executables, &enrichedExecutable{
-			ID:   exec.ID(),
-			Spec: exec,
+			ID:              exec.ID(),
+			Spec:            exec,
+			InheritedParams: exec.InheritedParams(),
 		})
 	}
 	jsonBytes, err := json.MarshalIndent(enriched, "", "  ")
@@ -2,6 +2,7 @@
 package executable
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -17,6 +18,9 @@ func execMarkdown(e *Executable) string {
 	if e.Timeout != 0 {
 		mkdwn += fmt.Sprintf("**Timeout:** %s\n", e.Timeout.String())
 	}
+	if e.Schedule != "" {
+		mkdwn += fmt.Sprintf("**Schedule:** `%s`\n", e.Schedule)
+	}
 	if len(e.Aliases) > 0 {
 		mkdwn += "**Aliases**\n"
 		for _, alias := range e.Aliases {
@@ -34,6 +38,7 @@ func execMarkdown(e *Executable) string {
 	}
 
 	mkdwn += execTypeMarkdown(e)
+	mkdwn += watchMarkdown(e.Watch)
 	mkdwn += fmt.Sprintf("\n\n_Executable can be found in_ [%s](%s)\n", e.flowFilePath, e.flowFilePath)
 	return mkdwn
 }
@@ -59,6 +64,37 @@ func execDescriptionMarkdown(e *Executable) string {
 	return mkdwn
 }
 
+func watchMarkdown(w *WatchConfig) string {
+	if w == nil {
+		return ""
+	}
+	mkdwn := "\n## Watch Configuration\n"
+	if len(w.Paths) > 0 {
+		mkdwn += "**Paths**\n"
+		for _, path := range w.Paths {
+			mkdwn += fmt.Sprintf("- `%s`\n", path)
+		}
+		mkdwn += "\n"
+	}
+	if len(w.Ignore) > 0 {
+		mkdwn += "**Ignore**\n"
+		for _, pattern := range w.Ignore {
+			mkdwn += fmt.Sprintf("- `%s`\n", pattern)
+		}
+		mkdwn += "\n"
+	}
+	if w.Debounce != 0 {
+		mkdwn += fmt.Sprintf("**Debounce:** %s\n", w.Debounce.String())
+	}
+	if w.Restart {
+		mkdwn += "**Restart:** enabled\n"
+	}
+	if w.Clear {
+		mkdwn += "**Clear:** enabled\n"
+	}
+	return mkdwn
+}
+
 func execTypeMarkdown(spec *Executable) string {
 	var mkdwn string
 	switch {
@@ -76,6 +112,12 @@ func execTypeMarkdown(spec *Executable) string {
 		mkdwn += serialExecMarkdown(spec.Env(), spec.Serial)
 	case spec.Parallel != nil:
 		mkdwn += parallelExecMarkdown(spec.Env(), spec.Parallel)
+	case spec.Service != nil:
+		mkdwn += serviceExecMarkdown(spec.Env(), spec.Service)
+	case spec.Wait != nil:
+		mkdwn += waitExecMarkdown(spec.Env(), spec.Wait)
+	case spec.Fs != nil:
+		mkdwn += fsExecMarkdown(spec.Env(), spec.Fs)
 	default:
 		mkdwn += "**generated markdown not supported for type**\n"
 	}
@@ -111,6 +153,9 @@ func launchExecMarkdown(e *ExecutableEnvironment, l *LaunchExecutableType) strin
 	if l.App != "" {
 		mkdwn += fmt.Sprintf("**App:** `%s`\n", l.App)
 	}
+	if len(l.AppArgs) > 0 {
+		mkdwn += fmt.Sprintf("**App Arguments:** `%s`\n", strings.Join(l.AppArgs, " "))
+	}
 	if l.URI != "" {
 		mkdwn += fmt.Sprintf("**URI:** [%s](%s)\n", l.URI, l.URI)
 	}
@@ -121,6 +166,90 @@ func launchExecMarkdown(e *ExecutableEnvironment, l *LaunchExecutableType) strin
 	return mkdwn
 }
 
+func serviceExecMarkdown(e *ExecutableEnvironment, s *ServiceExecutableType) string {
+	if s == nil {
+		return ""
+	}
+	mkdwn := "## Service Configuration\n"
+	if s.Dir != "" {
+		mkdwn += fmt.Sprintf("**Executed from:** `%s`\n", s.Dir)
+	}
+	mkdwn += fmt.Sprintf("**Command**\n```sh\n%s\n```\n", s.Cmd)
+	if p := s.Ready; p != nil {
+		switch {
+		case p.Http != "":
+			status := p.Status
+			if status == 0 {
+				status = DefaultReadyStatus
+			}
+			mkdwn += fmt.Sprintf("**Ready When:** `%s` returns a %d status\n", p.Http, status)
+		case p.Tcp != "":
+			mkdwn += fmt.Sprintf("**Ready When:** `%s` accepts connections\n", p.Tcp)
+		case p.Log != "":
+			mkdwn += fmt.Sprintf("**Ready When:** the output matches `%s`\n", p.Log)
+		case p.Cmd != "":
+			mkdwn += fmt.Sprintf("**Ready When:** `%s` succeeds\n", p.Cmd)
+		}
+		if p.Timeout != 0 {
+			mkdwn += fmt.Sprintf("**Ready Timeout:** %s\n", p.Timeout)
+		}
+	}
+	if s.KeepRunning {
+		mkdwn += "**Keep Running:** enabled\n"
+	}
+	if s.StopTimeout != 0 {
+		mkdwn += fmt.Sprintf("**Stop Timeout:** %s\n", s.StopTimeout)
+	}
+	mkdwn += execEnvTable(e)
+	return mkdwn
+}
+
+func waitExecMarkdown(e *ExecutableEnvironment, w *WaitExecutableType) string {
+	if w == nil {
+		return ""
+	}
+	mkdwn := "## Wait Configuration\n"
+	if w.Dir != "" {
+		mkdwn += fmt.Sprintf("**Executed from:** `%s`\n", w.Dir)
+	}
+	mkdwn += fmt.Sprintf("**Wait Until:** %s\n", w.Condition())
+	if w.Timeout != 0 {
+		mkdwn += fmt.Sprintf("**Timeout:** %s\n", w.Timeout)
+	}
+	if w.Interval != 0 {
+		mkdwn += fmt.Sprintf("**Interval:** %s\n", w.Interval)
+	}
+	mkdwn += execEnvTable(e)
+	return mkdwn
+}
+
+func fsExecMarkdown(e *ExecutableEnvironment, f *FsExecutableType) string {
+	if f == nil {
+		return ""
+	}
+	mkdwn := "## Filesystem Operations\n"
+	for i, op := range f.Ops {
+		switch {
+		case op.Copy != nil:
+			mkdwn += fmt.Sprintf("%d. copy `%s` to `%s`\n", i+1, op.Copy.Src, op.Copy.Dst)
+		case op.Move != nil:
+			mkdwn += fmt.Sprintf("%d. move `%s` to `%s`\n", i+1, op.Move.Src, op.Move.Dst)
+		case op.Mkdir != nil:
+			mkdwn += fmt.Sprintf("%d. mkdir `%s`\n", i+1, op.Mkdir.Path)
+		case op.Remove != nil:
+			mkdwn += fmt.Sprintf("%d. remove `%s`\n", i+1, op.Remove.Path)
+		case op.Template != nil:
+			mkdwn += fmt.Sprintf("%d. render `%s` to `%s`\n", i+1, op.Template.Src, op.Template.Dst)
+		case op.Symlink != nil:
+			mkdwn += fmt.Sprintf("%d. link `%s` to `%s`\n", i+1, op.Symlink.Dst, op.Symlink.Src)
+		case op.Chmod != nil:
+			mkdwn += fmt.Sprintf("%d. chmod %s `%s`\n", i+1, op.Chmod.Mode, op.Chmod.Path)
+		}
+	}
+	mkdwn += execEnvTable(e)
+	return mkdwn
+}
+
 func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) string {
 	if r == nil {
 		return ""
@@ -135,9 +264,53 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 	if r.LogResponse {
 		mkdwn += "**Log Response:** enabled\n"
 	}
+	if r.ViewResponse != "" {
+		mkdwn += fmt.Sprintf("**View Response As:** %s\n", r.ViewResponse)
+	}
 	if r.Body != "" {
 		mkdwn += fmt.Sprintf("**Body:**\n```\n%s\n```\n", r.Body)
 	}
+	if r.BodyFile != "" {
+		mkdwn += fmt.Sprintf("**Body File:** `%s`\n", r.BodyFile)
+	}
+	if r.JSON != nil {
+		if data, err := json.MarshalIndent(r.JSON, "", "  "); err == nil {
+			mkdwn += fmt.Sprintf("**JSON Body:**\n```json\n%s\n```\n", data)
+		}
+	}
+	if r.GraphQL != nil {
+		mkdwn += "**GraphQL Operation**"
+		if r.GraphQL.OperationName != "" {
+			mkdwn += fmt.Sprintf(" (%s)", r.GraphQL.OperationName)
+		}
+		if r.GraphQL.QueryFile != "" {
+			mkdwn += fmt.Sprintf(": `%s`\n", r.GraphQL.QueryFile)
+		} else {
+			mkdwn += fmt.Sprintf("\n```graphql\n%s\n```\n", strings.TrimSpace(r.GraphQL.Query))
+		}
+	}
+	if len(r.Query) > 0 {
+		mkdwn += "\n**Query Parameters**\n"
+		for k, v := range r.Query {
+			mkdwn += fmt.Sprintf("- %s: %s\n", k, v)
+		}
+	}
+	if len(r.Form) > 0 {
+		mkdwn += "\n**Form Fields**\n"
+		for k, v := range r.Form {
+			mkdwn += fmt.Sprintf("- %s: %s\n", k, v)
+		}
+	}
+	if len(r.Multipart) > 0 {
+		mkdwn += "\n**Multipart Fields**\n"
+		for _, part := range r.Multipart {
+			if part.File != "" {
+				mkdwn += fmt.Sprintf("- %s: file `%s`\n", part.Name, part.File)
+			} else {
+				mkdwn += fmt.Sprintf("- %s: %s\n", part.Name, part.Value)
+			}
+		}
+	}
 
 	if len(r.Headers) > 0 {
 		mkdwn += "\n**Headers**\n"
@@ -145,6 +318,30 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 			mkdwn += fmt.Sprintf("- %s: %s\n", k, v)
 		}
 	}
+	if r.Auth != nil {
+		mkdwn += requestAuthMarkdown(r.Auth)
+	}
+	if client := r.HTTPClientConfig().Markdown(); client != "" {
+		mkdwn += "\n**HTTP Client**\n" + client
+	}
+	if r.Paginate != nil {
+		mkdwn += fmt.Sprintf("**Pagination:** %s", r.Paginate.Strategy)
+		if r.Paginate.MaxPages > 0 {
+			mkdwn += fmt.Sprintf(" (max %d pages)", r.Paginate.MaxPages)
+		}
+		mkdwn += "\n"
+	}
+	if r.Retry != nil {
+		attempts := r.Retry.Attempts
+		if attempts == 0 {
+			attempts = 3
+		}
+		mkdwn += fmt.Sprintf("**Retry:** up to %d attempts", attempts)
+		if len(r.Retry.StatusCodes) > 0 {
+			mkdwn += fmt.Sprintf(" on status codes %v", r.Retry.StatusCodes)
+		}
+		mkdwn += "\n"
+	}
 	if len(r.ValidStatusCodes) > 0 {
 		mkdwn += "**Accepted Status Codes**\n"
 		for _, code := range r.ValidStatusCodes {
@@ -152,11 +349,31 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 		}
 	}
 
+	if len(r.Assert) > 0 {
+		mkdwn += "**Assertions**\n"
+		for _, assertion := range r.Assert {
+			mkdwn += fmt.Sprintf("- `%s`\n", assertion)
+		}
+	}
+	if len(r.Extract) > 0 {
+		mkdwn += "**Extracted Values**\n"
+		for _, rule := range r.Extract {
+			if rule.Jq != "" {
+				mkdwn += fmt.Sprintf("- %s: jq `%s`\n", rule.Key, rule.Jq)
+			} else {
+				mkdwn += fmt.Sprintf("- %s: `%s`\n", rule.Key, rule.Expr)
+			}
+		}
+	}
+
 	if r.ResponseFile != nil {
 		mkdwn += fmt.Sprintf("**Resonse Saved To:** %s\n", r.ResponseFile.Filename)
 		if r.ResponseFile.SaveAs != "" {
 			mkdwn += fmt.Sprintf("**Response Saved As:** %s\n", r.ResponseFile.SaveAs)
 		}
+		if r.ResponseFile.Sha256 != "" {
+			mkdwn += fmt.Sprintf("**Expected SHA-256:** `%s`\n", r.ResponseFile.Sha256)
+		}
 	}
 	if r.TransformResponse != "" {
 		mkdwn += fmt.Sprintf("**Transformation Expression:**\n ```\n%s\n```\n", r.TransformResponse)
@@ -166,6 +383,29 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 	return mkdwn
 }
 
+func requestAuthMarkdown(a *RequestAuth) string {
+	switch {
+	case a.Basic != nil:
+		return fmt.Sprintf("**Auth:** basic (user `%s`, password secret `%s`)\n", a.Basic.Username, a.Basic.SecretRef)
+	case a.Bearer != nil && a.Bearer.SecretRef != "":
+		return fmt.Sprintf("**Auth:** bearer token (secret `%s`)\n", a.Bearer.SecretRef)
+	case a.Bearer != nil:
+		return "**Auth:** bearer token\n"
+	case a.ApiKey != nil:
+		in := a.ApiKey.In
+		if in == "" {
+			in = RequestAPIKeyAuthInHeader
+		}
+		return fmt.Sprintf("**Auth:** api key (%s `%s`)\n", in, a.ApiKey.Name)
+	case a.Oauth2ClientCredentials != nil:
+		return fmt.Sprintf(
+			"**Auth:** oauth2 client credentials (client `%s`, token URL %s)\n",
+			a.Oauth2ClientCredentials.ClientID, a.Oauth2ClientCredentials.TokenURL,
+		)
+	}
+	return ""
+}
+
 func renderExecMarkdown(e *ExecutableEnvironment, r *RenderExecutableType) string {
 	if r == nil {
 		return ""
@@ -181,6 +421,22 @@ func renderExecMarkdown(e *ExecutableEnvironment, r *RenderExecutableType) strin
 	if r.TemplateDataFile != "" {
 		mkdwn += fmt.Sprintf("**Template Store File:** `%s`\n", r.TemplateDataFile)
 	}
+	for _, file := range r.TemplateDataFiles {
+		mkdwn += fmt.Sprintf("**Template Data File:** `%s`\n", file)
+	}
+	for _, source := range r.DataFrom {
+		mkdwn += fmt.Sprintf("**Template Data from `%s`:** `%s`\n", source.Key, source.Ref)
+	}
+	if r.RenderAs != "" && r.RenderAs != ViewFormatMarkdown {
+		mkdwn += fmt.Sprintf("**Render As:** %s\n", r.RenderAs)
+	}
+	if r.Output != "" && r.Output != RenderExecutableTypeOutputTui {
+		mkdwn += fmt.Sprintf("**Output:** %s", r.Output)
+		if r.OutputFile != "" {
+			mkdwn += fmt.Sprintf(" (`%s`)", r.OutputFile)
+		}
+		mkdwn += "\n"
+	}
 	mkdwn += execEnvTable(e)
 	return mkdwn
 }
@@ -201,6 +457,14 @@ func serialExecMarkdown(e *ExecutableEnvironment, s *SerialExecutableType) strin
 			mkdwn += fmt.Sprintf("%d. ref: %s\n", i+1, refCfg.Ref)
 		} else if refCfg.Cmd != "" {
 			mkdwn += fmt.Sprintf("%d. cmd: \n```sh\n%s\n```\n", i+1, refCfg.Cmd)
+		} else if refCfg.Prompt != nil {
+			keys := make([]string, 0, len(refCfg.Prompt.Fields))
+			for _, field := range refCfg.Prompt.Fields {
+				keys = append(keys, field.Key)
+			}
+			mkdwn += fmt.Sprintf("%d. prompt: %s\n", i+1, strings.Join(keys, ", "))
+		} else if refCfg.Wait != nil {
+			mkdwn += fmt.Sprintf("%d. wait until: %s\n", i+1, refCfg.Wait.Condition())
 		}
 		if refCfg.Retries > 0 {
 			mkdwn += fmt.Sprintf("  - **Retries:** %d\n", refCfg.Retries)
@@ -260,7 +524,12 @@ func execEnvTable(env *ExecutableEnvironment) string {
 	var table string
 	if len(env.Params) > 0 {
 		table += "### Parameters\n"
-		table += "| Env Key | Type | Value |\n| --- | --- | --- |\n"
+		inherited := env.HasInheritedParams()
+		if inherited {
+			table += "| Env Key | Type | Value | Source |\n| --- | --- | --- | --- |\n"
+		} else {
+			table += "| Env Key | Type | Value |\n| --- | --- | --- |\n"
+		}
 		for _, p := range env.Params {
 			var valueType, valueInput string
 			switch {
@@ -274,7 +543,11 @@ func execEnvTable(env *ExecutableEnvironment) string {
 				valueType = "prompt"
 				valueInput = p.Prompt
 			}
-			table += fmt.Sprintf("| `%s` | %s | %s |\n", p.EnvKey, valueType, valueInput)
+			if inherited {
+				table += fmt.Sprintf("| `%s` | %s | %s | %s |\n", p.EnvKey, valueType, valueInput, env.Source(p.EnvKey))
+			} else {
+				table += fmt.Sprintf("| `%s` | %s | %s |\n", p.EnvKey, valueType, valueInput)
+			}
 		}
 	}
 
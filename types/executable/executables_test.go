@@ -2,6 +2,7 @@ package executable_test
 
 import (
 	"fmt"
+	"os"
 	"slices"
 	"testing"
 
@@ -118,6 +119,50 @@ var _ = Describe("Executable", func() {
 		})
 	})
 
+	Describe("Env", func() {
+		BeforeEach(func() {
+			exec.Exec.Params = executable.ParameterList{
+				{EnvKey: "REGION", Text: "us-east-1"},
+			}
+			flowFile := &executable.FlowFile{
+				Env: executable.FlowFileEnv{"AWS_PROFILE": "dev", "REGION": "us-west-2"},
+				Params: executable.ParameterList{
+					{EnvKey: "TOKEN", SecretRef: "token"},
+				},
+				Executables: executable.ExecutableList{exec},
+			}
+			flowFile.SetWorkspaceParams(executable.EnvParams(
+				map[string]string{"AWS_PROFILE": "prod", "TEAM": "platform"}, nil,
+			))
+			exec.SetInheritedFields(flowFile)
+		})
+
+		It("should merge the flow file and workspace params with the executable's params", func() {
+			env := exec.Env()
+			Expect(env.Params).To(Equal(executable.ParameterList{
+				{EnvKey: "REGION", Text: "us-east-1"},
+				{EnvKey: "AWS_PROFILE", Text: "dev"},
+				{EnvKey: "TOKEN", SecretRef: "token"},
+				{EnvKey: "TEAM", Text: "platform"},
+			}))
+			Expect(env.Source("REGION")).To(Equal(executable.ParamSourceExecutable))
+			Expect(env.Source("AWS_PROFILE")).To(Equal(executable.ParamSourceFlowFile))
+			Expect(env.Source("TEAM")).To(Equal(executable.ParamSourceWorkspace))
+		})
+
+		It("InheritedParams should return only the params that are not defined by the executable", func() {
+			Expect(exec.InheritedParams()).To(Equal(executable.ParameterList{
+				{EnvKey: "AWS_PROFILE", Text: "dev"},
+				{EnvKey: "TOKEN", SecretRef: "token"},
+				{EnvKey: "TEAM", Text: "platform"},
+			}))
+		})
+
+		It("Markdown should show the source of each param", func() {
+			Expect(exec.Markdown()).To(ContainSubstring("| `TEAM` | text | platform | workspace |"))
+		})
+	})
+
 	DescribeTable("IsVisibleFromWorkspace", func(visibility *common.Visibility, wsMatch, expected bool) {
 		v := executable.ExecutableVisibility(*visibility)
 		exec.Visibility = &v
@@ -155,6 +200,32 @@ var _ = Describe("Executable", func() {
 		Entry("hidden from ws", common.VisibilityHidden.NewPointer(), true, false),
 		Entry("hidden from another ws", common.VisibilityHidden.NewPointer(), false, false),
 	)
+
+	Describe("Steps", func() {
+		It("should return no steps for other executable types", func() {
+			Expect(exec.Steps()).To(BeEmpty())
+		})
+
+		It("should return the execs of a serial executable in order", func() {
+			exec.Exec = nil
+			exec.Serial = &executable.SerialExecutableType{Execs: executable.SerialRefConfigList{
+				{Ref: "run first", Retries: 2},
+				{Cmd: "echo done", If: "success"},
+			}}
+			Expect(exec.Steps()).To(Equal([]executable.Step{
+				{Ref: "run first", Retries: 2},
+				{If: "success"},
+			}))
+		})
+
+		It("should mark the execs of a parallel executable", func() {
+			exec.Exec = nil
+			exec.Parallel = &executable.ParallelExecutableType{Execs: executable.ParallelRefConfigList{
+				{Ref: "run first"},
+			}}
+			Expect(exec.Steps()).To(Equal([]executable.Step{{Parallel: true, Ref: "run first"}}))
+		})
+	})
 })
 
 var _ = Describe("ExecutableList", func() {
@@ -309,3 +380,107 @@ var _ = Describe("ExecutableList", func() {
 		})
 	})
 })
+
+var _ = Describe("PromptConfig", func() {
+	DescribeTable("Validate", func(field executable.PromptField, valid bool) {
+		prompt := &executable.PromptConfig{Fields: []executable.PromptField{field}}
+		if valid {
+			Expect(prompt.Validate()).To(Succeed())
+		} else {
+			Expect(prompt.Validate()).NotTo(Succeed())
+		}
+	},
+		Entry("text field", executable.PromptField{Key: "NAME", Validation: "^[a-z]+$", Default: "flow"}, true),
+		Entry("missing key", executable.PromptField{Title: "Name"}, false),
+		Entry("select without options", executable.PromptField{Key: "ENV", Type: executable.PromptFieldTypeSelect}, false),
+		Entry("default that is not an option", executable.PromptField{
+			Key: "ENV", Type: executable.PromptFieldTypeSelect, Options: []string{"dev"}, Default: "prod",
+		}, false),
+		Entry("default that does not match the validation", executable.PromptField{
+			Key: "NAME", Validation: "^[a-z]+$", Default: "Flow",
+		}, false),
+	)
+
+	DescribeTable("ValidateAnswer", func(field executable.PromptField, answer string, valid bool) {
+		if valid {
+			Expect(field.ValidateAnswer(answer)).To(Succeed())
+		} else {
+			Expect(field.ValidateAnswer(answer)).NotTo(Succeed())
+		}
+	},
+		Entry("empty optional answer", executable.PromptField{Key: "NAME"}, "", true),
+		Entry("empty required answer", executable.PromptField{Key: "NAME", Required: true}, "", false),
+		Entry("confirm answer", executable.PromptField{Key: "OK", Type: executable.PromptFieldTypeConfirm}, "yes", true),
+		Entry("invalid confirm answer",
+			executable.PromptField{Key: "OK", Type: executable.PromptFieldTypeConfirm}, "sure", false),
+		Entry("multiselect answer", executable.PromptField{
+			Key: "REGIONS", Type: executable.PromptFieldTypeMultiselect, Options: []string{"us", "eu"},
+		}, "us, eu", true),
+	)
+})
+
+var _ = Describe("ReadinessProbe", func() {
+	DescribeTable("Validate", func(probe executable.ReadinessProbe, valid bool) {
+		if valid {
+			Expect(probe.Validate()).To(Succeed())
+		} else {
+			Expect(probe.Validate()).NotTo(Succeed())
+		}
+	},
+		Entry("http probe", executable.ReadinessProbe{Http: "http://localhost:8080/health"}, true),
+		Entry("log probe", executable.ReadinessProbe{Log: "listening on [0-9]+"}, true),
+		Entry("no check", executable.ReadinessProbe{}, false),
+		Entry("more than one check", executable.ReadinessProbe{Tcp: "localhost:5432", Cmd: "pg_isready"}, false),
+		Entry("invalid log expression", executable.ReadinessProbe{Log: "listening on ["}, false),
+	)
+})
+
+var _ = Describe("WaitExecutableType", func() {
+	DescribeTable("Validate", func(wait executable.WaitExecutableType, valid bool) {
+		if valid {
+			Expect(wait.Validate()).To(Succeed())
+		} else {
+			Expect(wait.Validate()).NotTo(Succeed())
+		}
+	},
+		Entry("file condition", executable.WaitExecutableType{File: "build/done", Absent: true}, true),
+		Entry("expr condition", executable.WaitExecutableType{Expr: `store["deployed"] == "true"`}, true),
+		Entry("no condition", executable.WaitExecutableType{}, false),
+		Entry("more than one condition", executable.WaitExecutableType{Tcp: "localhost:5432", Cmd: "pg_isready"}, false),
+		Entry("absent without a file", executable.WaitExecutableType{Tcp: "localhost:5432", Absent: true}, false),
+	)
+})
+
+var _ = Describe("FsExecutableType", func() {
+	DescribeTable("Validate", func(op executable.FsOperation, valid bool) {
+		fs := &executable.FsExecutableType{Ops: []executable.FsOperation{op}}
+		if valid {
+			Expect(fs.Validate()).To(Succeed())
+		} else {
+			Expect(fs.Validate()).NotTo(Succeed())
+		}
+	},
+		Entry("copy operation", executable.FsOperation{Copy: &executable.FsTransfer{Src: "*.txt", Dst: "out/"}}, true),
+		Entry("mkdir with a mode", executable.FsOperation{Mkdir: &executable.FsPath{Path: "out", Mode: "0700"}}, true),
+		Entry("no operation", executable.FsOperation{}, false),
+		Entry("more than one operation", executable.FsOperation{
+			Mkdir: &executable.FsPath{Path: "out"}, Remove: &executable.FsPath{Path: "tmp"},
+		}, false),
+		Entry("missing dst", executable.FsOperation{Move: &executable.FsTransfer{Src: "a"}}, false),
+		Entry("chmod without a mode", executable.FsOperation{Chmod: &executable.FsPath{Path: "bin/*"}}, false),
+		Entry("invalid mode", executable.FsOperation{Chmod: &executable.FsPath{Path: "bin/*", Mode: "rwx"}}, false),
+		Entry("mode out of range", executable.FsOperation{Chmod: &executable.FsPath{Path: "bin/*", Mode: "17777"}}, false),
+	)
+
+	DescribeTable("FileMode", func(mode string, expected os.FileMode) {
+		fileMode, err := (&executable.FsPath{Path: "bin", Mode: mode}).FileMode(executable.DefaultDirMode)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fileMode).To(Equal(expected))
+	},
+		Entry("no mode", "", executable.DefaultDirMode),
+		Entry("permissions", "0755", os.FileMode(0o755)),
+		Entry("setuid", "4755", os.ModeSetuid|0o755),
+		Entry("setgid", "2750", os.ModeSetgid|0o750),
+		Entry("sticky", "1777", os.ModeSticky|0o777),
+	)
+})
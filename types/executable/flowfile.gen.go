@@ -24,12 +24,34 @@ type FlowFile struct {
 	// defined within the flow file.
 	DescriptionFile string `json:"descriptionFile,omitempty" yaml:"descriptionFile,omitempty" mapstructure:"descriptionFile,omitempty"`
 
+	// Environment variables to be set for all executables defined within the flow
+	// file. Each value is applied as a
+	// `text` parameter, so the same precedence applies. A parameter in `params` with
+	// the same key takes precedence.
+	//
+	Env FlowFileEnv `json:"env,omitempty" yaml:"env,omitempty" mapstructure:"env,omitempty"`
+
 	// Executables corresponds to the JSON schema field "executables".
 	Executables ExecutableList `json:"executables,omitempty" yaml:"executables,omitempty" mapstructure:"executables,omitempty"`
 
+	// Fragments corresponds to the JSON schema field "fragments".
+	Fragments *Fragments `json:"fragments,omitempty" yaml:"fragments,omitempty" mapstructure:"fragments,omitempty"`
+
 	// FromFile corresponds to the JSON schema field "fromFile".
 	FromFile FromFile `json:"fromFile,omitempty" yaml:"fromFile,omitempty" mapstructure:"fromFile,omitempty"`
 
+	// Paths to other files in the workspace to import. The executables defined in an
+	// imported file are added to the
+	// flow file and its fragments can be included by the flow file's executables.
+	// Paths are relative to the flow file,
+	// or to the workspace root when they start with `//`.
+	//
+	// Imported files use the flow file format but cannot have the `.flow` extension,
+	// since flow files are also
+	// loaded on their own.
+	//
+	Imports []string `json:"imports,omitempty" yaml:"imports,omitempty" mapstructure:"imports,omitempty"`
+
 	// The namespace to be given to all executables in the flow file.
 	// If not set, the executables in the file will be grouped into the root (*)
 	// namespace.
@@ -40,6 +62,14 @@ type FlowFile struct {
 	//
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace,omitempty"`
 
+	// Parameters to be applied to all executables defined within the flow file. An
+	// executable's own parameters take
+	// precedence over the flow file's parameters with the same `envKey`, and the flow
+	// file's parameters take precedence
+	// over the workspace's.
+	//
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
 	// Tags to be applied to all executables defined within the flow file.
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
@@ -49,12 +79,40 @@ type FlowFile struct {
 	// workspace corresponds to the JSON schema field "workspaceName".
 	workspace string `json:"workspaceName,omitempty" yaml:"workspaceName,omitempty" mapstructure:"workspaceName,omitempty"`
 
+	// workspaceParams corresponds to the JSON schema field "workspaceParams".
+	workspaceParams ParameterList `json:"workspaceParams,omitempty" yaml:"workspaceParams,omitempty" mapstructure:"workspaceParams,omitempty"`
+
 	// workspacePath corresponds to the JSON schema field "workspacePath".
 	workspacePath string `json:"workspacePath,omitempty" yaml:"workspacePath,omitempty" mapstructure:"workspacePath,omitempty"`
 }
 
+// Environment variables to be set for all executables defined within the flow
+// file. Each value is applied as a
+// `text` parameter, so the same precedence applies. A parameter in `params` with
+// the same key takes precedence.
+type FlowFileEnv map[string]string
+
 type FlowFileVisibility common.Visibility
 
+// Named lists of params, args, and steps that can be included by the executables
+// of the flow file, and of the
+// flow files that import it. A fragment is included by adding an item with its
+// name in the `fragment` field to
+// the `params`, `args`, or `execs` list of an executable.
+type Fragments struct {
+	// Lists of arguments, keyed by the fragment name.
+	Args map[string]ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// Lists of parameters, keyed by the fragment name.
+	Params map[string]ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// Lists of serial or parallel execs, keyed by the fragment name. Steps that are
+	// included by a parallel
+	// executable can only set `cmd`, `ref`, `if`, `args`, and `retries`.
+	//
+	Steps map[string]SerialRefConfigList `json:"steps,omitempty" yaml:"steps,omitempty" mapstructure:"steps,omitempty"`
+}
+
 // A list of `.sh` files to convert into generated executables in the file's
 // executable group.
 type FromFile []string
@@ -29,6 +29,14 @@ func (f *FlowFile) SetContext(workspaceName, workspacePath, configPath string) {
 	}
 }
 
+// SetWorkspaceParams sets the params that the flow file's executables inherit from the workspace.
+func (f *FlowFile) SetWorkspaceParams(params ParameterList) {
+	f.workspaceParams = params
+	for _, exec := range f.Executables {
+		exec.workspaceParams = params
+	}
+}
+
 func (f *FlowFile) SetDefaults() {
 	if f.Visibility == nil || *f.Visibility == "" {
 		v := FlowFileVisibility(common.VisibilityPrivate)
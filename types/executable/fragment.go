@@ -0,0 +1,151 @@
+package executable
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ExpandFragments replaces the fragment items in the params of the flow file and in the params, args, and execs of its
+// executables with the items of the fragments that they name.
+func (f *FlowFile) ExpandFragments(fragments *Fragments) error {
+	if fragments == nil {
+		fragments = &Fragments{}
+	}
+	params, err := expandFragments("params", f.Params, func(p Parameter) string { return p.Fragment },
+		fragments.paramsFragment)
+	if err != nil {
+		return err
+	}
+	f.Params = params
+	for _, e := range f.Executables {
+		if err := e.ExpandFragments(fragments); err != nil {
+			return fmt.Errorf("%s %s - %w", e.Verb, e.Name, err)
+		}
+	}
+	return nil
+}
+
+// ExpandFragments replaces the fragment items in the params, args, and execs of the executable with the items of the
+// fragments that they name.
+func (e *Executable) ExpandFragments(fragments *Fragments) error {
+	if fragments == nil {
+		fragments = &Fragments{}
+	}
+	typeFields := []any{
+		e.Exec,
+		e.Launch,
+		e.Request,
+		e.Render,
+		e.Serial,
+		e.Parallel,
+		e.Service,
+		e.Wait,
+		e.Fs,
+	}
+	for _, field := range typeFields {
+		v := reflect.ValueOf(field)
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			continue
+		}
+		typeElem := v.Elem()
+		for i := 0; i < typeElem.NumField(); i++ {
+			fieldVal := typeElem.Field(i)
+			if !fieldVal.CanSet() || fieldVal.Kind() != reflect.Slice || fieldVal.IsNil() {
+				continue
+			}
+			var expanded any
+			var err error
+			switch list := fieldVal.Interface().(type) {
+			case ParameterList:
+				expanded, err = expandFragments("params", list, func(p Parameter) string { return p.Fragment },
+					fragments.paramsFragment)
+			case ArgumentList:
+				expanded, err = expandFragments("args", list, func(a Argument) string { return a.Fragment },
+					fragments.argsFragment)
+			case SerialRefConfigList:
+				expanded, err = expandFragments("steps", list, func(s SerialRefConfig) string { return s.Fragment },
+					fragments.serialStepsFragment)
+			case ParallelRefConfigList:
+				expanded, err = expandFragments("steps", list, func(s ParallelRefConfig) string { return s.Fragment },
+					fragments.parallelStepsFragment)
+			default:
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			fieldVal.Set(reflect.ValueOf(expanded))
+		}
+	}
+	return nil
+}
+
+func expandFragments[S ~[]T, T any](
+	kind string, list S, fragmentName func(T) string, lookup func(string) (S, bool, error),
+) (S, error) {
+	if !slices.ContainsFunc(list, func(item T) bool { return fragmentName(item) != "" }) {
+		return list, nil
+	}
+	expanded := make(S, 0, len(list))
+	for _, item := range list {
+		name := fragmentName(item)
+		if name == "" {
+			expanded = append(expanded, item)
+			continue
+		}
+		items, found, err := lookup(name)
+		if err != nil {
+			return nil, err
+		} else if !found {
+			return nil, fmt.Errorf("%s fragment %s not found", kind, name)
+		}
+		if slices.ContainsFunc(items, func(item T) bool { return fragmentName(item) != "" }) {
+			return nil, fmt.Errorf("%s fragment %s cannot include another fragment", kind, name)
+		}
+		expanded = append(expanded, items...)
+	}
+	return expanded, nil
+}
+
+func (f *Fragments) paramsFragment(name string) (ParameterList, bool, error) {
+	params, found := f.Params[name]
+	return params, found, nil
+}
+
+func (f *Fragments) argsFragment(name string) (ArgumentList, bool, error) {
+	args, found := f.Args[name]
+	return args, found, nil
+}
+
+func (f *Fragments) serialStepsFragment(name string) (SerialRefConfigList, bool, error) {
+	steps, found := f.Steps[name]
+	return steps, found, nil
+}
+
+// parallelStepsFragment converts the steps of the fragment to parallel execs. Steps that use serial only fields
+// cannot be run in parallel.
+func (f *Fragments) parallelStepsFragment(name string) (ParallelRefConfigList, bool, error) {
+	steps, found := f.Steps[name]
+	if !found {
+		return nil, false, nil
+	}
+	execs := make(ParallelRefConfigList, 0, len(steps))
+	for _, s := range steps {
+		if s.Prompt != nil || s.Wait != nil || s.ReviewRequired {
+			return nil, true, fmt.Errorf(
+				"steps fragment %s cannot be included in parallel execs; prompt, wait, and reviewRequired are "+
+					"only supported in serial execs", name,
+			)
+		}
+		execs = append(execs, ParallelRefConfig{
+			Fragment: s.Fragment,
+			Cmd:      s.Cmd,
+			Ref:      s.Ref,
+			If:       s.If,
+			Args:     s.Args,
+			Retries:  s.Retries,
+		})
+	}
+	return execs, true, nil
+}
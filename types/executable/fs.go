@@ -0,0 +1,101 @@
+package executable
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jahvon/flow/internal/utils"
+)
+
+// DefaultDirMode is the permissions of the directories created by the mkdir operation when a mode is not set.
+const DefaultDirMode os.FileMode = 0750
+
+func (f *FsExecutableType) Validate() error {
+	if f == nil {
+		return nil
+	}
+	if len(f.Ops) == 0 {
+		return fmt.Errorf("fs executable must have at least one operation")
+	}
+	for i, op := range f.Ops {
+		if err := op.Validate(); err != nil {
+			return fmt.Errorf("fs operation %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (o *FsOperation) Validate() error {
+	err := utils.ValidateOneOf(
+		"fs operation", o.Copy, o.Move, o.Mkdir, o.Remove, o.Template, o.Symlink, o.Chmod,
+	)
+	if err != nil {
+		return err
+	}
+	for _, t := range []*FsTransfer{o.Copy, o.Move, o.Template, o.Symlink} {
+		if t != nil && (t.Src == "" || t.Dst == "") {
+			return fmt.Errorf("%s src and dst must be set", o.Name())
+		}
+	}
+	for _, p := range []*FsPath{o.Mkdir, o.Remove, o.Chmod} {
+		if p == nil {
+			continue
+		}
+		if p.Path == "" {
+			return fmt.Errorf("%s path must be set", o.Name())
+		}
+		if _, err := p.FileMode(0); err != nil {
+			return fmt.Errorf("%s: %w", o.Name(), err)
+		}
+	}
+	if o.Chmod != nil && o.Chmod.Mode == "" {
+		return fmt.Errorf("chmod mode must be set")
+	}
+	return nil
+}
+
+// Name returns the name of the operation that is set.
+func (o *FsOperation) Name() string {
+	switch {
+	case o.Copy != nil:
+		return "copy"
+	case o.Move != nil:
+		return "move"
+	case o.Mkdir != nil:
+		return "mkdir"
+	case o.Remove != nil:
+		return "remove"
+	case o.Template != nil:
+		return "template"
+	case o.Symlink != nil:
+		return "symlink"
+	case o.Chmod != nil:
+		return "chmod"
+	default:
+		return ""
+	}
+}
+
+// FileMode parses the octal mode of the path. The default mode is returned if the mode is not set.
+func (p *FsPath) FileMode(defaultMode os.FileMode) (os.FileMode, error) {
+	if p.Mode == "" {
+		return defaultMode, nil
+	}
+	mode, err := strconv.ParseUint(p.Mode, 8, 32)
+	if err != nil || mode > 0o7777 {
+		return 0, fmt.Errorf("invalid mode %s; the mode must be in octal notation (e.g. 0755)", p.Mode)
+	}
+	// The setuid, setgid, and sticky bits are not in the same position in an os.FileMode as in octal notation.
+	fileMode := os.FileMode(mode) & os.ModePerm
+	if mode&0o4000 != 0 {
+		fileMode |= os.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		fileMode |= os.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		fileMode |= os.ModeSticky
+	}
+	return fileMode, nil
+}
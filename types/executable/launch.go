@@ -0,0 +1,15 @@
+package executable
+
+import (
+	"fmt"
+)
+
+func (l *LaunchExecutableType) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if len(l.AppArgs) > 0 && l.App == "" {
+		return fmt.Errorf("launch appArgs requires an app to be set")
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/jahvon/flow/internal/utils"
@@ -13,6 +14,37 @@ const (
 	ReservedEnvVarPrefix = "FLOW_"
 )
 
+// ParamSource is where the value of a param is defined. Params defined by the executable take precedence over the
+// ones defined by its flow file, which take precedence over the ones defined by its workspace.
+type ParamSource string
+
+const (
+	ParamSourceExecutable ParamSource = "executable"
+	ParamSourceFlowFile   ParamSource = "flowfile"
+	ParamSourceWorkspace  ParamSource = "workspace"
+)
+
+// EnvParams returns the params with a text param added for each of the env entries, sorted by key. Env entries with
+// the same key as one of the params are ignored.
+func EnvParams(env map[string]string, params ParameterList) ParameterList {
+	if len(env) == 0 {
+		return params
+	}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		if slices.ContainsFunc(params, func(p Parameter) bool { return p.EnvKey == key }) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	merged := make(ParameterList, 0, len(keys)+len(params))
+	for _, key := range keys {
+		merged = append(merged, Parameter{EnvKey: key, Text: env[key]})
+	}
+	return append(merged, params...)
+}
+
 func (p *Parameter) Validate() error {
 	if err := utils.ValidateOneOf("parameter type", p.Text, p.SecretRef, p.Prompt); err != nil {
 		return err
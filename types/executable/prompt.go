@@ -0,0 +1,139 @@
+package executable
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jahvon/flow/internal/utils"
+)
+
+const (
+	// AssumeYesEnv is set when prompts should be answered with their default answers and confirmations accepted.
+	AssumeYesEnv = "FLOW_ASSUME_YES"
+	// AnswersFileEnv is set to the path of a YAML or JSON file that maps prompt keys to their answers.
+	AnswersFileEnv = "FLOW_ANSWERS_FILE"
+)
+
+// AssumeYes returns true if prompts should be answered with their default answers and confirmations accepted.
+func AssumeYes() bool {
+	val, _ := strconv.ParseBool(os.Getenv(AssumeYesEnv))
+	return val
+}
+
+func (s *SerialExecutableType) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for i, refConfig := range s.Execs {
+		err := utils.ValidateOneOf(
+			"serial step cmd, ref, prompt, or wait", refConfig.Cmd, refConfig.Ref, refConfig.Prompt, refConfig.Wait,
+		)
+		if err != nil {
+			return fmt.Errorf("serial step %d: %w", i+1, err)
+		}
+		if err := refConfig.Prompt.Validate(); err != nil {
+			return fmt.Errorf("serial step %d: %w", i+1, err)
+		}
+		if err := refConfig.Wait.Validate(); err != nil {
+			return fmt.Errorf("serial step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (p *PromptConfig) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Fields) == 0 {
+		return fmt.Errorf("prompt must have at least one field")
+	}
+	keys := make(map[string]bool, len(p.Fields))
+	for _, field := range p.Fields {
+		if err := field.Validate(); err != nil {
+			return err
+		}
+		if keys[field.Key] {
+			return fmt.Errorf("prompt field key %s is used more than once", field.Key)
+		}
+		keys[field.Key] = true
+	}
+	return nil
+}
+
+func (f *PromptField) Validate() error {
+	if f.Key == "" {
+		return fmt.Errorf("prompt field key cannot be empty")
+	}
+	switch f.Type {
+	case "", PromptFieldTypeText, PromptFieldTypeMasked, PromptFieldTypeConfirm:
+		if len(f.Options) > 0 {
+			return fmt.Errorf("prompt field %s: options can only be set for select and multiselect fields", f.Key)
+		}
+	case PromptFieldTypeSelect, PromptFieldTypeMultiselect:
+		if len(f.Options) == 0 {
+			return fmt.Errorf("prompt field %s: options are required for %s fields", f.Key, f.Type)
+		}
+	default:
+		return fmt.Errorf("prompt field %s: unknown type %s", f.Key, f.Type)
+	}
+	if f.Validation != "" {
+		if _, err := regexp.Compile(f.Validation); err != nil {
+			return fmt.Errorf("prompt field %s: invalid validation expression: %w", f.Key, err)
+		}
+	}
+	if f.Default != "" {
+		if err := f.ValidateAnswer(f.Default); err != nil {
+			return fmt.Errorf("prompt field %s: invalid default: %w", f.Key, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAnswer returns an error if the answer is not valid for the field. Multiselect answers are comma-separated.
+func (f *PromptField) ValidateAnswer(answer string) error {
+	if answer == "" {
+		if f.Required {
+			return fmt.Errorf("an answer is required for %s", f.Key)
+		}
+		return nil
+	}
+	//nolint:exhaustive
+	switch f.Type {
+	case PromptFieldTypeConfirm:
+		if _, err := ParseConfirmAnswer(answer); err != nil {
+			return err
+		}
+	case PromptFieldTypeSelect:
+		if !slices.Contains(f.Options, answer) {
+			return fmt.Errorf("%s is not an option for %s", answer, f.Key)
+		}
+	case PromptFieldTypeMultiselect:
+		for _, opt := range strings.Split(answer, ",") {
+			if !slices.Contains(f.Options, strings.TrimSpace(opt)) {
+				return fmt.Errorf("%s is not an option for %s", opt, f.Key)
+			}
+		}
+	default:
+		if f.Validation != "" && !regexp.MustCompile(f.Validation).MatchString(answer) {
+			return fmt.Errorf("answer for %s does not match %s", f.Key, f.Validation)
+		}
+	}
+	return nil
+}
+
+// ParseConfirmAnswer parses a yes/no or boolean answer.
+func ParseConfirmAnswer(answer string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes", "true", "1":
+		return true, nil
+	case "n", "no", "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%s is not a yes or no answer", answer)
+	}
+}
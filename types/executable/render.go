@@ -0,0 +1,42 @@
+package executable
+
+import (
+	"fmt"
+)
+
+func (r *RenderExecutableType) Validate() error {
+	if r == nil {
+		return nil
+	}
+	switch r.Output {
+	case "", RenderExecutableTypeOutputTui, RenderExecutableTypeOutputStdout:
+	case RenderExecutableTypeOutputFile, RenderExecutableTypeOutputHtml:
+		if r.OutputFile == "" {
+			return fmt.Errorf("render output %s requires an outputFile", r.Output)
+		}
+	default:
+		return fmt.Errorf("invalid render output %s", r.Output)
+	}
+	switch r.RenderAs {
+	case "", ViewFormatMarkdown:
+	case ViewFormatJson, ViewFormatYaml, ViewFormatTable:
+		if r.Output == RenderExecutableTypeOutputHtml {
+			return fmt.Errorf("render output html is only supported for markdown content")
+		}
+	default:
+		return fmt.Errorf("invalid renderAs format %s", r.RenderAs)
+	}
+	keys := make(map[string]bool, len(r.DataFrom))
+	for _, source := range r.DataFrom {
+		if source.Key == "" {
+			return fmt.Errorf("render dataFrom sources must have a key")
+		} else if keys[source.Key] {
+			return fmt.Errorf("duplicate render dataFrom key %s", source.Key)
+		}
+		keys[source.Key] = true
+		if source.Ref == "" {
+			return fmt.Errorf("render dataFrom source %s must have a ref", source.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package executable
+
+import (
+	"fmt"
+
+	"github.com/jahvon/flow/internal/utils"
+	"github.com/jahvon/flow/types/common"
+)
+
+func (r *RequestExecutableType) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var bodies int
+	for _, set := range []bool{
+		r.Body != "", len(r.Form) > 0, len(r.Multipart) > 0, r.BodyFile != "", r.JSON != nil, r.GraphQL != nil,
+	} {
+		if set {
+			bodies++
+		}
+	}
+	if bodies > 1 {
+		return fmt.Errorf("only one of body, form, multipart, bodyFile, json, or graphql can be set")
+	}
+	if r.GraphQL != nil {
+		if err := utils.ValidateOneOf("graphql query or queryFile", r.GraphQL.Query, r.GraphQL.QueryFile); err != nil {
+			return err
+		}
+	}
+	for _, part := range r.Multipart {
+		if part.Name == "" {
+			return fmt.Errorf("multipart parts must have a name")
+		}
+		if err := utils.ValidateOneOf("multipart value or file", part.Value, part.File); err != nil {
+			return fmt.Errorf("invalid multipart part %s - %w", part.Name, err)
+		}
+	}
+	for _, rule := range r.Extract {
+		if rule.Key == "" {
+			return fmt.Errorf("extract rules must have a key")
+		}
+		if err := utils.ValidateOneOf("extract jq or expr", rule.Jq, rule.Expr); err != nil {
+			return fmt.Errorf("invalid extract rule %s - %w", rule.Key, err)
+		}
+	}
+	if p := r.Paginate; p != nil {
+		switch p.Strategy {
+		case RequestPaginateStrategyLinkHeader, RequestPaginateStrategyPage:
+		case RequestPaginateStrategyCursor:
+			if p.Cursor == "" {
+				return fmt.Errorf("cursor pagination requires a cursor query")
+			}
+		default:
+			return fmt.Errorf("invalid pagination strategy %s", p.Strategy)
+		}
+		if p.MaxPages < 0 {
+			return fmt.Errorf("pagination maxPages cannot be negative")
+		}
+	}
+	if r.Auth != nil {
+		if err := r.Auth.Validate(); err != nil {
+			return fmt.Errorf("invalid request auth - %w", err)
+		}
+	}
+	if r.Retry != nil {
+		if r.Retry.Attempts < 0 || r.Retry.Backoff < 0 || r.Retry.MaxBackoff < 0 {
+			return fmt.Errorf("request retry attempts, backoff, and maxBackoff cannot be negative")
+		}
+	}
+	if err := r.HTTPClientConfig().Validate(); err != nil {
+		return fmt.Errorf("invalid request httpClient - %w", err)
+	}
+	return nil
+}
+
+func (r *RequestExecutableType) HTTPClientConfig() *common.HTTPClientConfig {
+	if r == nil {
+		return nil
+	}
+	return (*common.HTTPClientConfig)(r.HttpClient)
+}
+
+// SecretRefs returns the vault secret references used by the request's configuration.
+func (r *RequestExecutableType) SecretRefs() []string {
+	if r == nil {
+		return nil
+	}
+	return append(r.Auth.SecretRefs(), r.HTTPClientConfig().SecretRefs()...)
+}
+
+func (a *RequestAuth) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := utils.ValidateOneOf("auth type", a.Basic, a.Bearer, a.ApiKey, a.Oauth2ClientCredentials); err != nil {
+		return err
+	}
+
+	switch {
+	case a.Basic != nil:
+		if a.Basic.Username == "" || a.Basic.SecretRef == "" {
+			return fmt.Errorf("basic auth requires a username and secretRef")
+		}
+	case a.Bearer != nil:
+		return utils.ValidateOneOf("bearer token source", a.Bearer.Token, a.Bearer.SecretRef)
+	case a.ApiKey != nil:
+		if a.ApiKey.Name == "" {
+			return fmt.Errorf("api key auth requires a name")
+		}
+		return utils.ValidateOneOf("api key source", a.ApiKey.Value, a.ApiKey.SecretRef)
+	case a.Oauth2ClientCredentials != nil:
+		o := a.Oauth2ClientCredentials
+		if o.TokenURL == "" || o.ClientID == "" || o.ClientSecretRef == "" {
+			return fmt.Errorf("oauth2 client credentials requires a tokenURL, clientID, and clientSecretRef")
+		}
+	}
+	return nil
+}
+
+func (a *RequestAuth) SecretRefs() []string {
+	if a == nil {
+		return nil
+	}
+	var refs []string
+	switch {
+	case a.Basic != nil:
+		refs = append(refs, a.Basic.SecretRef)
+	case a.Bearer != nil && a.Bearer.SecretRef != "":
+		refs = append(refs, a.Bearer.SecretRef)
+	case a.ApiKey != nil && a.ApiKey.SecretRef != "":
+		refs = append(refs, a.ApiKey.SecretRef)
+	case a.Oauth2ClientCredentials != nil:
+		refs = append(refs, a.Oauth2ClientCredentials.ClientSecretRef)
+	}
+	return refs
+}
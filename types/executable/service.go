@@ -0,0 +1,62 @@
+package executable
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jahvon/flow/internal/utils"
+)
+
+const (
+	DefaultReadyTimeout  = 30 * time.Second
+	DefaultReadyInterval = time.Second
+	DefaultReadyStatus   = 200
+	DefaultStopTimeout   = 10 * time.Second
+)
+
+func (s *ServiceExecutableType) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Cmd == "" {
+		return fmt.Errorf("service cmd cannot be empty")
+	}
+	return s.Ready.Validate()
+}
+
+// GetStopTimeout returns the amount of time to wait for the service to exit before it is killed.
+func (s *ServiceExecutableType) GetStopTimeout() time.Duration {
+	if s.StopTimeout == 0 {
+		return DefaultStopTimeout
+	}
+	return s.StopTimeout
+}
+
+func (p *ReadinessProbe) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := utils.ValidateOneOf("readiness probe", p.Http, p.Tcp, p.Log, p.Cmd); err != nil {
+		return err
+	}
+	if p.Log != "" {
+		if _, err := regexp.Compile(p.Log); err != nil {
+			return fmt.Errorf("invalid readiness log expression: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetDefaults sets the timeout, interval, and HTTP status of the probe if they are not set.
+func (p *ReadinessProbe) SetDefaults() {
+	if p.Timeout == 0 {
+		p.Timeout = DefaultReadyTimeout
+	}
+	if p.Interval == 0 {
+		p.Interval = DefaultReadyInterval
+	}
+	if p.Status == 0 {
+		p.Status = DefaultReadyStatus
+	}
+}
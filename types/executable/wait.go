@@ -0,0 +1,62 @@
+package executable
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jahvon/flow/internal/utils"
+)
+
+const (
+	DefaultWaitTimeout  = 5 * time.Minute
+	DefaultWaitInterval = 2 * time.Second
+	DefaultWaitStatus   = 200
+)
+
+func (w *WaitExecutableType) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if err := utils.ValidateOneOf("wait condition", w.Http, w.Tcp, w.File, w.Cmd, w.Expr); err != nil {
+		return err
+	}
+	if w.Absent && w.File == "" {
+		return fmt.Errorf("wait absent can only be set with a file condition")
+	}
+	return nil
+}
+
+// SetDefaults sets the timeout, interval, and HTTP status of the wait if they are not set.
+func (w *WaitExecutableType) SetDefaults() {
+	if w.Timeout == 0 {
+		w.Timeout = DefaultWaitTimeout
+	}
+	if w.Interval == 0 {
+		w.Interval = DefaultWaitInterval
+	}
+	if w.Status == 0 {
+		w.Status = DefaultWaitStatus
+	}
+}
+
+// Condition returns a short description of the condition being waited for.
+func (w *WaitExecutableType) Condition() string {
+	switch {
+	case w.Http != "":
+		status := w.Status
+		if status == 0 {
+			status = DefaultWaitStatus
+		}
+		return fmt.Sprintf("%s returns a %d status", w.Http, status)
+	case w.Tcp != "":
+		return fmt.Sprintf("%s accepts connections", w.Tcp)
+	case w.File != "" && w.Absent:
+		return fmt.Sprintf("%s is removed", w.File)
+	case w.File != "":
+		return fmt.Sprintf("%s exists", w.File)
+	case w.Cmd != "":
+		return fmt.Sprintf("`%s` succeeds", w.Cmd)
+	default:
+		return fmt.Sprintf("`%s` is true", w.Expr)
+	}
+}
@@ -3,6 +3,7 @@
 package workspace
 
 import "github.com/jahvon/flow/types/common"
+import "github.com/jahvon/flow/types/executable"
 
 type ExecutableFilter struct {
 	// A list of directories to exclude from the executable search.
@@ -30,14 +31,34 @@ type Workspace struct {
 	// The display name of the workspace. This is used in the interactive UI.
 	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty" mapstructure:"displayName,omitempty"`
 
+	// Environment variables to be set for all executables in the workspace. Each
+	// value is applied as a `text`
+	// parameter, so the same precedence applies. A parameter in `params` with the
+	// same key takes precedence.
+	//
+	Env WorkspaceEnv `json:"env,omitempty" yaml:"env,omitempty" mapstructure:"env,omitempty"`
+
 	// Executables corresponds to the JSON schema field "executables".
 	Executables *ExecutableFilter `json:"executables,omitempty" yaml:"executables,omitempty" mapstructure:"executables,omitempty"`
 
 	// location corresponds to the JSON schema field "location".
 	location string `json:"location,omitempty" yaml:"location,omitempty" mapstructure:"location,omitempty"`
 
+	// Parameters to be applied to all executables in the workspace. Parameters
+	// defined by a flow file or an
+	// executable take precedence over the workspace's parameters with the same
+	// `envKey`.
+	//
+	Params executable.ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
 	// Tags corresponds to the JSON schema field "tags".
 	Tags WorkspaceTags `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 }
 
+// Environment variables to be set for all executables in the workspace. Each value
+// is applied as a `text`
+// parameter, so the same precedence applies. A parameter in `params` with the same
+// key takes precedence.
+type WorkspaceEnv map[string]string
+
 type WorkspaceTags common.Tags
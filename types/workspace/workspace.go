@@ -9,6 +9,7 @@ import (
 
 	"github.com/jahvon/flow/internal/utils"
 	"github.com/jahvon/flow/types/common"
+	"github.com/jahvon/flow/types/executable"
 )
 
 //go:generate go run github.com/atombender/go-jsonschema@v0.16.0 -et --only-models -p workspace -o workspace.gen.go schema.yaml
@@ -32,6 +33,11 @@ func (w *Workspace) SetContext(name, location string) {
 	w.location = location
 }
 
+// InheritedParams returns the params and env that the executables of the workspace inherit.
+func (w *Workspace) InheritedParams() executable.ParameterList {
+	return executable.EnvParams(w.Env, w.Params)
+}
+
 func (w *Workspace) YAML() (string, error) {
 	yamlBytes, err := yaml.Marshal(w)
 	if err != nil {